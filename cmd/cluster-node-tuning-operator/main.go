@@ -49,6 +49,7 @@ import (
 	"github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/hypershift"
 	hcpcomponents "github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/hypershift/components"
 	hcpstatus "github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/hypershift/status"
+	tunedexplain "github.com/openshift/cluster-node-tuning-operator/pkg/tuned/cmd/explain"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/tuned/cmd/operand"
 	tunedrender "github.com/openshift/cluster-node-tuning-operator/pkg/tuned/cmd/render"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
@@ -110,6 +111,7 @@ func prepareCommands() {
 	if !config.InHyperShift() {
 		rootCmd.AddCommand(render.NewRenderCommand())
 		rootCmd.AddCommand(tunedrender.NewRenderBootCmdMCCommand())
+		rootCmd.AddCommand(tunedexplain.NewExplainCommand())
 	}
 	rootCmd.AddCommand(operand.NewTunedCommand())
 }
@@ -192,6 +194,10 @@ func operatorRun() {
 		if err = (&performancev2.PerformanceProfile{}).SetupWebhookWithManager(mgr); err != nil {
 			klog.Exitf("unable to create PerformanceProfile v2 webhook: %v", err)
 		}
+
+		if err = (&tunedv1.Tuned{}).SetupWebhookWithManager(mgr); err != nil {
+			klog.Exitf("unable to create Tuned webhook: %v", err)
+		}
 	} else {
 		operatorNamespace := config.OperatorNamespace()
 		fg, err := setupFeatureGates(context.TODO(), restConfig, operatorNamespace)
@@ -3,6 +3,7 @@ package v1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 
 	operatorv1 "github.com/openshift/api/operator/v1"
 )
@@ -27,13 +28,44 @@ const (
 	TunedBootcmdlineAnnotationKey string = "tuned.openshift.io/bootcmdline"
 
 	// TunedDeferredUpdate request the tuned daemons to defer the update of the rendered profile
-	// until the next restart.
+	// until the next restart or maintenance window.
 	TunedDeferredUpdate string = "tuned.openshift.io/deferred"
+
+	// TunedDryRun set to "true" on a Tuned resource requests the operator to only preview
+	// the effects of the Tuned resource in its status without updating the Profiles.
+	TunedDryRun string = "tuned.openshift.io/dry-run"
+
+	// TunedDiscardDeferredUpdate set on a Profile to the fingerprint of the deferred update pending
+	// on its node (status.deferredUpdate.fingerprint) requests the tuned daemon to discard the update.
+	TunedDiscardDeferredUpdate string = "tuned.openshift.io/discard-deferred"
+
+	// TunedRevisionAnnotationKey is a Profile annotation with the name of the ControllerRevision
+	// holding the rendered profile set carried by the Profile.
+	TunedRevisionAnnotationKey string = "tuned.openshift.io/revision"
+
+	// TunedRevisionTunedAnnotationKey is a ControllerRevision annotation of a rendered profile set
+	// with the name of the ControllerRevision of the Tuned resource the profile set was selected by.
+	TunedRevisionTunedAnnotationKey string = "tuned.openshift.io/tuned-revision"
+
+	// TunedRevisionTypeLabelKey is a ControllerRevision label with the type of the revision
+	// (TunedRevisionTypeTuned or TunedRevisionTypeRendered).
+	TunedRevisionTypeLabelKey string = "tuned.openshift.io/revision-type"
+
+	// TunedRevisionTunedLabelKey is a ControllerRevision label with the name of the Tuned resource
+	// the revision is a snapshot of.  Only set for revisions of type TunedRevisionTypeTuned.
+	TunedRevisionTunedLabelKey string = "tuned.openshift.io/tuned"
+
+	// TunedRevisionTypeTuned is the type of ControllerRevisions with snapshots of Tuned resources.
+	TunedRevisionTypeTuned = "tuned"
+
+	// TunedRevisionTypeRendered is the type of ControllerRevisions with rendered profile sets.
+	TunedRevisionTypeRendered = "rendered"
 )
 
 /////////////////////////////////////////////////////////////////////////////////
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:subresource:status
 
 // Tuned is a collection of rules that allows cluster-wide deployment
 // of node-level sysctls and more flexibility to add custom tuning
@@ -67,12 +99,29 @@ type TunedSpec struct {
 }
 
 // A Tuned profile.
+// +kubebuilder:validation:XValidation:rule="has(self.data) != has(self.dataFrom)",message="exactly one of data and dataFrom must be set"
 type TunedProfile struct {
 	// Name of the Tuned profile to be used in the recommend section.
 	// +kubebuilder:validation:MinLength=1
 	Name *string `json:"name"`
 	// Specification of the Tuned profile to be consumed by the Tuned daemon.
-	Data *string `json:"data"`
+	// +optional
+	Data *string `json:"data,omitempty"`
+	// Source of the specification of the Tuned profile.  Mutually exclusive
+	// with data.
+	// +optional
+	DataFrom *TunedProfileDataSource `json:"dataFrom,omitempty"`
+}
+
+// A source of the specification of a Tuned profile.
+type TunedProfileDataSource struct {
+	// Name of the ConfigMap in the namespace of the Tuned object holding the
+	// specification of the Tuned profile.
+	// +kubebuilder:validation:MinLength=1
+	ConfigMap string `json:"configMap"`
+	// Key of the ConfigMap data holding the specification of the Tuned profile.
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
 }
 
 // Selection logic for a single Tuned profile.
@@ -86,6 +135,11 @@ type TunedRecommend struct {
 	Priority *uint64 `json:"priority"`
 	// Rules governing application of a Tuned profile connected by logical OR operator.
 	Match []TunedMatch `json:"match,omitempty"`
+	// NodeSelector is a label query over Node labels.  If specified, only Nodes matching
+	// the selector are considered for the Tuned profile.  NodeSelector is connected with
+	// the Match and MachineConfigLabels rules by logical AND operator.
+	// +optional
+	NodeSelector *metav1.LabelSelector `json:"nodeSelector,omitempty"`
 	// MachineConfigLabels specifies the labels for a MachineConfig. The MachineConfig is created
 	// automatically to apply additional host settings (e.g. kernel boot parameters) profile 'Profile'
 	// needs and can only be applied by creating a MachineConfig. This involves finding all
@@ -96,9 +150,45 @@ type TunedRecommend struct {
 	// Optional operand configuration.
 	// +optional
 	Operand OperandConfig `json:"operand,omitempty"`
+
+	// Optional rollout strategy for Profile updates caused by this recommend item.
+	// If omitted, all affected Profiles are updated at once.
+	// +optional
+	Rollout *RolloutStrategy `json:"rollout,omitempty"`
+}
+
+// RolloutStrategy controls progressive updates of Profiles in waves.
+type RolloutStrategy struct {
+	// BatchSize is the number of Nodes selected by the recommend item which have
+	// their Profile updated in a single rollout wave.  The next wave starts only once
+	// the TuneD daemons applied all updated Profiles of the previous wave.  Value can
+	// be an absolute number (ex: 5) or a percentage of Nodes selected by the recommend
+	// item (ex: 10%).  Absolute number is calculated from percentage by rounding up.
+	// If omitted, Profiles are updated as soon as MaxUnavailable allows.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	BatchSize *intstr.IntOrString `json:"batchSize,omitempty"`
+
+	// MaxUnavailable is the maximum number of Nodes selected by the recommend item
+	// which can have their Profile updated and not yet applied by the TuneD daemon
+	// or degraded at the same time.  Value can be an absolute number (ex: 5) or
+	// a percentage of Nodes selected by the recommend item (ex: 10%).  Absolute
+	// number is calculated from percentage by rounding up.  Defaults to BatchSize
+	// if set, 1 otherwise.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// PauseOnDegraded halts the rollout once any of the updated Profiles reports
+	// the Degraded condition.  Otherwise, degraded Profiles only count against
+	// MaxUnavailable.  Defaults to true.
+	// +kubebuilder:default=true
+	// +optional
+	PauseOnDegraded *bool `json:"pauseOnDegraded,omitempty"`
 }
 
 // Rules governing application of a Tuned profile.
+// +kubebuilder:validation:XValidation:rule="!has(self.negate) || !self.negate || has(self.label)",message="a negated match rule requires a label"
 type TunedMatch struct {
 	// Node or Pod label name.
 	Label *string `json:"label"`
@@ -107,6 +197,10 @@ type TunedMatch struct {
 	// Match type: [node/pod]. If omitted, "node" is assumed.
 	// +kubebuilder:validation:Enum={"node","pod"}
 	Type *string `json:"type,omitempty"`
+	// Negate the label rule (logical NOT): match Nodes without the Node label (value), or Nodes
+	// running no Pod with the Pod label (value).  Additional rules in match are not negated.
+	// +optional
+	Negate *bool `json:"negate,omitempty"`
 
 	// Additional rules governing application of the tuned profile connected by logical AND operator.
 	Match []TunedMatch `json:"match,omitempty"`
@@ -123,6 +217,16 @@ type OperandConfig struct {
 
 	// +optional
 	TuneDConfig TuneDConfig `json:"tunedConfig,omitempty"`
+
+	// revert to the last TuneD profile which applied cleanly when the TuneD daemon
+	// reports errors applying a new one: true/false (default is false)
+	// +optional
+	RollbackOnDegraded bool `json:"rollbackOnDegraded,omitempty"`
+
+	// interval of verifying the sysctl and sysfs settings of the active TuneD profile
+	// still match the node state, e.g. "10m"; verification is off if unset or zero
+	// +optional
+	VerifyInterval *metav1.Duration `json:"verifyInterval,omitempty"`
 }
 
 // Global configuration for the TuneD daemon as defined in tuned-main.conf
@@ -134,6 +238,159 @@ type TuneDConfig struct {
 
 // TunedStatus is the status for a Tuned resource.
 type TunedStatus struct {
+	// conditions represents the state of the Tuned resource as observed by the operator
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +optional
+	Conditions []TunedStatusCondition `json:"conditions,omitempty"  patchStrategy:"merge" patchMergeKey:"type"`
+
+	// recommend reports the Node selection results for the individual items
+	// of the spec.recommend list, in the same order
+	// +optional
+	Recommend []TunedRecommendStatus `json:"recommend,omitempty"`
+
+	// preview reports the effects the Tuned resource would have if it was not
+	// in the dry-run mode; only set for Tuned resources in the dry-run mode
+	// +optional
+	Preview *TunedPreview `json:"preview,omitempty"`
+
+	// bootcmdlineConflicts reports the MachineConfigPools (or NodePools on HyperShift)
+	// whose Nodes disagree on the kernel arguments calculated by TuneD and where
+	// at least one variant of the kernel arguments comes from this Tuned resource
+	// +optional
+	BootcmdlineConflicts []BootcmdlineConflict `json:"bootcmdlineConflicts,omitempty"`
+}
+
+// TunedStatusCondition represents a partial state of the Tuned resource.
+// +k8s:deepcopy-gen=true
+type TunedStatusCondition struct {
+	// type specifies the aspect reported by this condition.
+	// +kubebuilder:validation:Required
+	// +required
+	Type TunedConditionType `json:"type"`
+
+	// status of the condition, one of True, False, Unknown.
+	// +kubebuilder:validation:Required
+	// +required
+	Status corev1.ConditionStatus `json:"status"`
+
+	// lastTransitionTime is the time of the last update to the current status property.
+	// +kubebuilder:validation:Required
+	// +required
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// reason is the CamelCase reason for the condition's current status.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// message provides additional information about the current condition.
+	// This is only to be consumed by humans.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// TunedConditionType is an aspect of the Tuned resource state.
+type TunedConditionType string
+
+const (
+	// TunedValid indicates that all TuneD profiles of the Tuned resource
+	// have a name and INI-parsable data and all nodeSelectors of its recommend
+	// items are valid.
+	TunedValid TunedConditionType = "Valid"
+
+	// TunedConflicting indicates that one or more TuneD profiles of the Tuned
+	// resource share a name with a TuneD profile of a different content
+	// defined in another Tuned resource.
+	TunedConflicting TunedConditionType = "Conflicting"
+
+	// TunedInUse indicates that at least one item of the Tuned resource's
+	// recommend section selected a TuneD profile for a Node.
+	TunedInUse TunedConditionType = "InUse"
+
+	// TunedRolloutPaused indicates that a rollout of Profile updates for at least
+	// one item of the Tuned resource's recommend section is paused due to
+	// degraded Profiles.
+	TunedRolloutPaused TunedConditionType = "RolloutPaused"
+
+	// TunedPriorityConflict indicates that an item of the Tuned resource's recommend
+	// section and another recommend item with the same priority, but a different
+	// TuneD profile, both match a Node.
+	TunedPriorityConflict TunedConditionType = "PriorityConflict"
+)
+
+// TunedRecommendStatus reports the Node selection results for a single
+// item of the Tuned resource's recommend section.
+type TunedRecommendStatus struct {
+	// Name of the recommended Tuned profile.
+	Profile string `json:"profile"`
+
+	// Tuned profile priority of the recommend item.
+	// +optional
+	Priority *uint64 `json:"priority,omitempty"`
+
+	// Number of Nodes this recommend item selected the Tuned profile for.
+	MatchedNodes int32 `json:"matchedNodes"`
+
+	// Number of Nodes with Profiles the rollout of this recommend item already updated.
+	// Only reported for recommend items with a rollout strategy.
+	// +optional
+	UpdatedNodes *int32 `json:"updatedNodes,omitempty"`
+
+	// Indicates the rollout of this recommend item is paused due to degraded Profiles.
+	// +optional
+	RolloutPaused bool `json:"rolloutPaused,omitempty"`
+
+	// Indicates why this recommend item selected the Tuned profile for no Node, if so.
+	// Unreachable: a catch-all recommend item evaluated before this one always wins.
+	// NoMatch: this recommend item selected the Tuned profile for no Node on the last resync.
+	// +optional
+	Unused TunedRecommendUnusedReason `json:"unused,omitempty"`
+
+	// The catch-all recommend item which makes this recommend item unreachable, in the
+	// <Tuned name>/recommend[<index>] format.
+	// +optional
+	ShadowedBy string `json:"shadowedBy,omitempty"`
+}
+
+// TunedRecommendUnusedReason is the reason a recommend item selected the Tuned
+// profile for no Node.
+type TunedRecommendUnusedReason string
+
+const (
+	// TunedRecommendUnreachable means a catch-all recommend item evaluated before
+	// the recommend item always selects its Tuned profile first.
+	TunedRecommendUnreachable TunedRecommendUnusedReason = "Unreachable"
+
+	// TunedRecommendNoMatch means the recommend item selected the Tuned profile
+	// for no Node on the last resync.
+	TunedRecommendNoMatch TunedRecommendUnusedReason = "NoMatch"
+)
+
+// TunedPreview reports the effects of a Tuned resource in the dry-run mode.
+type TunedPreview struct {
+	// Nodes which would switch their TuneD profile.
+	// +optional
+	Nodes []TunedPreviewNode `json:"nodes,omitempty"`
+
+	// Names of MachineConfigPools which would need a new MachineConfig, i.e. their Nodes would be rebooted.
+	// +optional
+	MachineConfigPools []string `json:"machineConfigPools,omitempty"`
+}
+
+// TunedPreviewNode reports a TuneD profile switch of a single Node.
+type TunedPreviewNode struct {
+	// Name of the Node.
+	Name string `json:"name"`
+
+	// TuneD profile currently selected for the Node.
+	CurrentProfile string `json:"currentProfile"`
+
+	// TuneD profile that would be selected for the Node.
+	Profile string `json:"profile"`
+
+	// Indicates the Node would need a new MachineConfig.
+	// +optional
+	MachineConfig bool `json:"machineConfig,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -180,6 +437,12 @@ type ProfileConfig struct {
 	// Name of the cloud provider as taken from the Node providerID: <ProviderName>://<ProviderSpecificNodeID>
 	// +optional
 	ProviderName string `json:"providerName,omitempty"`
+	// option to revert to the last TuneD profile which applied cleanly on TuneD daemon errors
+	// +optional
+	RollbackOnDegraded bool `json:"rollbackOnDegraded,omitempty"`
+	// interval of verifying the settings of the active TuneD profile still match the node state
+	// +optional
+	VerifyInterval *metav1.Duration `json:"verifyInterval,omitempty"`
 }
 
 // ProfileStatus is the status for a Profile resource; the status is for internal use only
@@ -188,11 +451,93 @@ type ProfileStatus struct {
 	// the current profile in use by the Tuned daemon
 	TunedProfile string `json:"tunedProfile"`
 
+	// the name of the ControllerRevision with the rendered profile set in use by the Tuned daemon
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
 	// conditions represents the state of the per-node Profile application
 	// +patchMergeKey=type
 	// +patchStrategy=merge
 	// +optional
 	Conditions []ProfileStatusCondition `json:"conditions,omitempty"  patchStrategy:"merge" patchMergeKey:"type"`
+
+	// the deferred update pending on the node, if any
+	// +optional
+	DeferredUpdate *ProfileDeferredUpdate `json:"deferredUpdate,omitempty"`
+
+	// the disagreement of the Nodes in the MachineConfigPool (or NodePool on HyperShift)
+	// of this node on the kernel arguments calculated by TuneD, if any; set by the operator
+	// +optional
+	BootcmdlineConflict *BootcmdlineConflict `json:"bootcmdlineConflict,omitempty"`
+
+	// the node-specific variables ${node.label:<key>} and ${node.annotation:<key>} used
+	// in the TuneD profiles which reference labels or annotations missing on the node
+	// or with values containing new lines or '['; set by the operator
+	// +optional
+	MissingNodeVariables []string `json:"missingNodeVariables,omitempty"`
+
+	// the recommend item of a Tuned object which selected the TuneD profile for the node;
+	// set by the operator
+	// +optional
+	SelectedBy *ProfileSelection `json:"selectedBy,omitempty"`
+}
+
+// ProfileSelection identifies the recommend item of a Tuned object which selected
+// the TuneD profile for a node and the match rules which selected it.
+type ProfileSelection struct {
+	// name of the Tuned object
+	Tuned string `json:"tuned"`
+
+	// index of the recommend item in the Tuned object's spec.recommend list
+	RecommendIndex int `json:"recommendIndex"`
+
+	// priority of the recommend item
+	// +optional
+	Priority *uint64 `json:"priority,omitempty"`
+
+	// the match rules which selected the TuneD profile, outermost first, such as
+	// "node label <key>=<value>", "pod label <key>", "machineConfigLabels <key>=<value>"
+	// or "nodePool <name>"; empty for a catch-all recommend item
+	// +optional
+	Match []string `json:"match,omitempty"`
+}
+
+// BootcmdlineConflict reports the Nodes of a MachineConfigPool (or NodePool on HyperShift)
+// which disagree on the kernel arguments calculated by TuneD.
+type BootcmdlineConflict struct {
+	// name of the MachineConfigPool (or NodePool on HyperShift)
+	Pool string `json:"pool"`
+
+	// the variants of the kernel arguments calculated for the Nodes of the pool
+	Variants []BootcmdlineVariant `json:"variants"`
+}
+
+// BootcmdlineVariant is a variant of the kernel arguments calculated by TuneD
+// for the Nodes of a pool.
+type BootcmdlineVariant struct {
+	// the kernel arguments calculated by TuneD
+	Bootcmdline string `json:"bootcmdline"`
+
+	// the names of the Nodes with the kernel arguments
+	Nodes []string `json:"nodes"`
+
+	// the recommend items which selected the TuneD profiles for the Nodes,
+	// in the <Tuned name>/recommend[<index>] format
+	// +optional
+	Recommend []string `json:"recommend,omitempty"`
+}
+
+// ProfileDeferredUpdate describes a deferred update pending on the node.
+type ProfileDeferredUpdate struct {
+	// fingerprint of the pending TuneD profile set
+	Fingerprint string `json:"fingerprint"`
+
+	// the TuneD profile to be used once the deferred update takes effect
+	TunedProfile string `json:"tunedProfile"`
+
+	// summary of the differences between the pending and the effective TuneD profile set
+	// +optional
+	Diff []string `json:"diff,omitempty"`
 }
 
 // ProfileStatusCondition represents a partial state of the per-node Profile application.
@@ -235,6 +580,36 @@ const (
 	// application.  To conclude the profile application was successful,
 	// both TunedProfileApplied and TunedDegraded need to be queried.
 	TunedDegraded ProfileConditionType = "Degraded"
+
+	// TunedRolledBack indicates the Tuned daemon reverted to the last profile
+	// which applied cleanly after it issued errors during profile application.
+	TunedRolledBack ProfileConditionType = "RolledBack"
+
+	// TunedDrifted indicates the sysctl or sysfs settings of the active profile
+	// no longer match the node state.
+	TunedDrifted ProfileConditionType = "Drifted"
+
+	// TunedRebootRequired indicates the kernel command-line of the running kernel
+	// does not match the kernel arguments calculated by the Tuned daemon.
+	TunedRebootRequired ProfileConditionType = "RebootRequired"
+)
+
+// Reasons of the Events recorded on Profile and Node objects.
+const (
+	// EventReasonProfileApplied means the Tuned daemon applied a profile.
+	EventReasonProfileApplied = "ProfileApplied"
+
+	// EventReasonProfileDegraded means the Tuned daemon issued errors during
+	// profile application.
+	EventReasonProfileDegraded = "ProfileDegraded"
+
+	// EventReasonDeferredUpdatePending means a profile update is waiting for
+	// the next node restart.
+	EventReasonDeferredUpdatePending = "DeferredUpdatePending"
+
+	// EventReasonMachineConfigUpdated means the operator created or updated
+	// a MachineConfig for a profile.
+	EventReasonMachineConfigUpdated = "MachineConfigUpdated"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,323 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/ini.v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/cluster-node-tuning-operator/pkg/tunedprofile"
+)
+
+// tunedProfilesDirSystem is the directory with TuneD profiles shipped with TuneD.
+// The operator and operand share the same container image.
+var tunedProfilesDirSystem = "/usr/lib/tuned"
+
+var validatorContext = context.TODO()
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *Tuned) ValidateCreate() (admission.Warnings, error) {
+	klog.Infof("create validation for Tuned %q", r.Name)
+
+	return r.validateCreateOrUpdate()
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *Tuned) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
+	klog.Infof("update validation for Tuned %q", r.Name)
+
+	return r.validateCreateOrUpdate()
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *Tuned) ValidateDelete() (admission.Warnings, error) {
+	return admission.Warnings{}, nil
+}
+
+func (r *Tuned) validateCreateOrUpdate() (admission.Warnings, error) {
+	var others []Tuned
+
+	if validatorClient != nil {
+		tunedList := &TunedList{}
+		if err := validatorClient.List(validatorContext, tunedList, client.InNamespace(r.Namespace)); err != nil {
+			return admission.Warnings{}, apierrors.NewInternalError(err)
+		}
+		for _, tuned := range tunedList.Items {
+			// exclude the current Tuned from the list, its new version is validated
+			if tuned.Name == r.Name {
+				continue
+			}
+			others = append(others, tuned)
+		}
+	}
+
+	warnings, allErrs := r.validate(others)
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+
+	return warnings, apierrors.NewInvalid(
+		schema.GroupKind{Group: SchemeGroupVersion.Group, Kind: "Tuned"},
+		r.Name, allErrs)
+}
+
+// validate validates Tuned 'r' against all other existing Tuned objects 'others'.
+// Returns non-fatal warnings and a list of errors that should cause the Tuned
+// object to be rejected.
+func (r *Tuned) validate(others []Tuned) (admission.Warnings, field.ErrorList) {
+	var (
+		allErrs  field.ErrorList
+		warnings admission.Warnings
+	)
+
+	// TuneD profile name -> included TuneD profile names for all known TuneD profiles defined in Tuned objects
+	includes := map[string][]string{}
+	for _, tuned := range others {
+		for _, profile := range tuned.Spec.Profile {
+			if profile.Name == nil {
+				continue
+			}
+			if profile.Data == nil {
+				if profile.DataFrom != nil {
+					// Known, but its includes are not available without the ConfigMap data.
+					includes[*profile.Name] = nil
+				}
+				continue
+			}
+			cfg, err := tunedprofile.Parse([]byte(*profile.Data))
+			if err != nil {
+				continue
+			}
+			includes[*profile.Name], _ = tunedProfileIncludes(cfg)
+		}
+	}
+
+	// TuneD profile name -> included TuneD profile names which are loaded conditionally
+	optional := map[string]map[string]bool{}
+	profilePath := field.NewPath("spec", "profile")
+	for i, profile := range r.Spec.Profile {
+		if profile.Name == nil || len(*profile.Name) == 0 {
+			allErrs = append(allErrs, field.Required(profilePath.Index(i).Child("name"), "TuneD profile name is required"))
+			continue
+		}
+		if profile.DataFrom != nil {
+			if profile.Data != nil {
+				allErrs = append(allErrs, field.Forbidden(profilePath.Index(i).Child("dataFrom"), "TuneD profile data and dataFrom are mutually exclusive"))
+			}
+			// The TuneD profile data sourced from a ConfigMap is validated when the profile is rendered.
+			includes[*profile.Name] = nil
+			continue
+		}
+		if profile.Data == nil {
+			allErrs = append(allErrs, field.Required(profilePath.Index(i).Child("data"), "TuneD profile data or dataFrom is required"))
+			continue
+		}
+		cfg, err := tunedprofile.Parse([]byte(*profile.Data))
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(profilePath.Index(i).Child("data"), *profile.Name, fmt.Sprintf("failed to parse TuneD profile: %v", err)))
+			continue
+		}
+		includes[*profile.Name], optional[*profile.Name] = tunedProfileIncludes(cfg)
+	}
+
+	// TuneD profile names which are part of an already reported include cycle
+	inCycle := map[string]bool{}
+	for i, profile := range r.Spec.Profile {
+		if profile.Name == nil || includes[*profile.Name] == nil {
+			continue
+		}
+		name := *profile.Name
+		for _, include := range includes[name] {
+			if optional[name][include] {
+				// Conditionally loaded TuneD profiles need not exist.
+				continue
+			}
+			if include == name {
+				// A TuneD profile including a system TuneD profile of the same name.
+				if !tunedSystemProfileExists(include) {
+					warnings = append(warnings, fmt.Sprintf("TuneD profile %q includes unknown TuneD profile %q", name, include))
+				}
+				continue
+			}
+			if _, found := includes[include]; found {
+				continue
+			}
+			if !tunedSystemProfileExists(include) {
+				warnings = append(warnings, fmt.Sprintf("TuneD profile %q includes unknown TuneD profile %q", name, include))
+			}
+		}
+		if inCycle[name] {
+			continue
+		}
+		if cycle := tunedProfileIncludeCycle(name, includes); cycle != nil {
+			for _, p := range cycle {
+				inCycle[p] = true
+			}
+			allErrs = append(allErrs, field.Invalid(profilePath.Index(i).Child("data"), name, fmt.Sprintf("TuneD profile include cycle: %s", strings.Join(cycle, " -> "))))
+		}
+	}
+
+	recommendPath := field.NewPath("spec", "recommend")
+	for i, recommend := range r.Spec.Recommend {
+		allErrs = append(allErrs, validateMatch(recommend.Match, recommendPath.Index(i).Child("match"))...)
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(recommend.NodeSelector, metav1validation.LabelSelectorValidationOptions{}, recommendPath.Index(i).Child("nodeSelector"))...)
+	}
+
+	warnings = append(warnings, r.validateRecommendPriorities(others)...)
+
+	return warnings, allErrs
+}
+
+// validateMatch returns errors for the match rules 'match' at path 'path' and their
+// subtrees.  The CRD schema only covers the top level of the match rules.
+func validateMatch(match []TunedMatch, path *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, m := range match {
+		if m.Negate != nil && *m.Negate && m.Label == nil {
+			allErrs = append(allErrs, field.Required(path.Index(i).Child("label"), "a negated match rule requires a label"))
+		}
+		allErrs = append(allErrs, validateMatch(m.Match, path.Index(i).Child("match"))...)
+	}
+
+	return allErrs
+}
+
+// validateRecommendPriorities returns warnings for recommend items of Tuned 'r'
+// which share their priority with other recommend items of 'r' or 'others'.
+func (r *Tuned) validateRecommendPriorities(others []Tuned) admission.Warnings {
+	var warnings admission.Warnings
+
+	for i, recommend := range r.Spec.Recommend {
+		if recommend.Priority == nil {
+			continue
+		}
+		for j := 0; j < i; j++ {
+			if r.Spec.Recommend[j].Priority != nil && *r.Spec.Recommend[j].Priority == *recommend.Priority {
+				warnings = append(warnings, fmt.Sprintf("spec.recommend[%d] and spec.recommend[%d] have the same priority %d; please use a different priority for your custom profiles", j, i, *recommend.Priority))
+			}
+		}
+		for _, tuned := range others {
+			for j, otherRecommend := range tuned.Spec.Recommend {
+				if otherRecommend.Priority != nil && *otherRecommend.Priority == *recommend.Priority {
+					warnings = append(warnings, fmt.Sprintf("spec.recommend[%d] has the same priority %d as spec.recommend[%d] of Tuned %q; please use a different priority for your custom profiles", i, *recommend.Priority, j, tuned.Name))
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// tunedProfileIncludes returns the names of TuneD profiles included by a TuneD profile
+// 'cfg' and a set of those which are loaded conditionally.  Conditional loading characters
+// ('-') are removed and profile names with TuneD built-in functions or variables are
+// skipped as they can only be expanded by the operand.
+func tunedProfileIncludes(cfg *ini.File) ([]string, map[string]bool) {
+	includes := []string{}
+	optional := map[string]bool{}
+
+	for _, include := range tunedprofile.Includes(cfg, tunedUnexpanded) {
+		if include.Conditional {
+			optional[include.Name] = true
+		}
+		if strings.Contains(include.Name, "${") {
+			continue
+		}
+		includes = append(includes, include.Name)
+	}
+
+	return includes, optional
+}
+
+// tunedUnexpanded replaces TuneD built-in functions and variables in 's' by "${}",
+// so that their arguments are not split into TuneD profile names.
+func tunedUnexpanded(s string) string {
+	var (
+		b     strings.Builder
+		depth int
+	)
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case strings.HasPrefix(s[i:], "${"):
+			if depth == 0 {
+				b.WriteString("${}")
+			}
+			depth++
+			i++
+		case depth > 0 && s[i] == '}':
+			depth--
+		case depth == 0:
+			b.WriteByte(s[i])
+		}
+	}
+
+	return b.String()
+}
+
+// tunedProfileIncludeCycle returns the TuneD profile names forming an include cycle
+// reachable from TuneD profile 'name' or nil if there is no such cycle.  'includes'
+// is a map of TuneD profile names to TuneD profile names they include.
+func tunedProfileIncludeCycle(name string, includes map[string][]string) []string {
+	var (
+		path    []string
+		visited = map[string]bool{}
+		visit   func(string) []string
+	)
+
+	visit = func(profile string) []string {
+		for i, p := range path {
+			if p == profile {
+				return append(append([]string{}, path[i:]...), profile)
+			}
+		}
+		if visited[profile] {
+			return nil
+		}
+		visited[profile] = true
+
+		path = append(path, profile)
+		included := append([]string{}, includes[profile]...)
+		sort.Strings(included)
+		for _, include := range included {
+			if include == profile {
+				// Including a system profile of the same name is not a cycle.
+				continue
+			}
+			if cycle := visit(include); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+
+		return nil
+	}
+
+	return visit(name)
+}
+
+// tunedSystemProfileExists returns true if system TuneD profile 'name' exists
+// or the system TuneD profiles cannot be read.
+func tunedSystemProfileExists(name string) bool {
+	if _, err := os.Stat(tunedProfilesDirSystem); err != nil {
+		// We cannot tell, do not issue false warnings.
+		return true
+	}
+
+	_, err := os.Stat(filepath.Join(tunedProfilesDirSystem, name, "tuned.conf"))
+	return !errors.Is(err, os.ErrNotExist)
+}
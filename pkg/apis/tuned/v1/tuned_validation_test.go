@@ -0,0 +1,259 @@
+package v1
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func newValidationTestTuned(name string, profiles map[string]string, priorities ...uint64) Tuned {
+	tuned := Tuned{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openshift-cluster-node-tuning-operator",
+		},
+	}
+	for _, profileName := range sortedKeys(profiles) {
+		tuned.Spec.Profile = append(tuned.Spec.Profile, TunedProfile{
+			Name: ptr.To(profileName),
+			Data: ptr.To(profiles[profileName]),
+		})
+	}
+	for _, priority := range priorities {
+		tuned.Spec.Recommend = append(tuned.Spec.Recommend, TunedRecommend{
+			Profile:  ptr.To(name),
+			Priority: ptr.To(priority),
+		})
+	}
+	return tuned
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestTunedValidate(t *testing.T) {
+	systemDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(systemDir, "throughput-performance"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(systemDir, "throughput-performance", "tuned.conf"), []byte("[main]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer func(dir string) { tunedProfilesDirSystem = dir }(tunedProfilesDirSystem)
+	tunedProfilesDirSystem = systemDir
+
+	others := []Tuned{
+		newValidationTestTuned("default", map[string]string{
+			"openshift":      "[main]\ninclude=throughput-performance",
+			"openshift-node": "[main]\ninclude=openshift",
+		}, 20, 30),
+		newValidationTestTuned("loop", map[string]string{
+			"loop-a": "[main]\ninclude=loop-b",
+		}),
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "configmap"},
+			Spec: TunedSpec{
+				Profile: []TunedProfile{
+					{
+						Name:     ptr.To("from-configmap"),
+						DataFrom: &TunedProfileDataSource{ConfigMap: "configmap", Key: "tuned.conf"},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		tuned            Tuned
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		{
+			// Valid Tuned including both Tuned-defined and system profiles.
+			tuned: newValidationTestTuned("custom", map[string]string{
+				"custom": "[main]\ninclude=openshift-node,throughput-performance\n[sysctl]\nvm.swappiness=10",
+			}, 10),
+		},
+		{
+			// Broken INI body.
+			tuned: newValidationTestTuned("custom", map[string]string{
+				"custom": "[main\ninclude=openshift-node",
+			}, 10),
+			expectedErrors: []string{"failed to parse TuneD profile"},
+		},
+		{
+			// Include of a non-existent profile; conditional includes are fine.
+			tuned: newValidationTestTuned("custom", map[string]string{
+				"custom": "[main]\ninclude=does-not-exist,-optional-does-not-exist",
+			}, 10),
+			expectedWarnings: []string{`TuneD profile "custom" includes unknown TuneD profile "does-not-exist"`},
+		},
+		{
+			// Includes separated like TuneD does; built-in functions are expanded by the operand.
+			tuned: newValidationTestTuned("custom", map[string]string{
+				"custom": "[main]\ninclude=openshift-node , does-not-exist;${f:virt_check:virtual-guest,does-not-exist-either}",
+			}, 10),
+			expectedWarnings: []string{`TuneD profile "custom" includes unknown TuneD profile "does-not-exist"`},
+		},
+		{
+			// Whitespace does not separate TuneD profile names.
+			tuned: newValidationTestTuned("custom", map[string]string{
+				"custom": "[main]\ninclude=openshift-node throughput-performance",
+			}, 10),
+			expectedWarnings: []string{`TuneD profile "custom" includes unknown TuneD profile "openshift-node throughput-performance"`},
+		},
+		{
+			// Including a system profile of the same name.
+			tuned: newValidationTestTuned("custom", map[string]string{
+				"throughput-performance": "[main]\ninclude=throughput-performance",
+			}, 10),
+		},
+		{
+			// Include cycle within the Tuned.
+			tuned: newValidationTestTuned("custom", map[string]string{
+				"a": "[main]\ninclude=b",
+				"b": "[main]\ninclude=c",
+				"c": "[main]\ninclude=a",
+			}, 10),
+			expectedErrors: []string{"TuneD profile include cycle: a -> b -> c -> a"},
+		},
+		{
+			// Include cycle across Tuned objects.
+			tuned: newValidationTestTuned("custom", map[string]string{
+				"loop-b": "[main]\ninclude=loop-a",
+			}, 10),
+			expectedErrors: []string{"TuneD profile include cycle: loop-b -> loop-a -> loop-b"},
+		},
+		{
+			// TuneD profile data sourced from a ConfigMap.
+			tuned: Tuned{
+				ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+				Spec: TunedSpec{
+					Profile: []TunedProfile{
+						{
+							Name:     ptr.To("custom"),
+							DataFrom: &TunedProfileDataSource{ConfigMap: "custom", Key: "tuned.conf"},
+						},
+					},
+				},
+			},
+		},
+		{
+			// Includes of TuneD profiles sourced from ConfigMaps in this and other Tuned objects.
+			tuned: Tuned{
+				ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+				Spec: TunedSpec{
+					Profile: []TunedProfile{
+						{
+							Name: ptr.To("custom"),
+							Data: ptr.To("[main]\ninclude=custom-configmap,from-configmap"),
+						},
+						{
+							Name:     ptr.To("custom-configmap"),
+							DataFrom: &TunedProfileDataSource{ConfigMap: "custom", Key: "tuned.conf"},
+						},
+					},
+				},
+			},
+		},
+		{
+			// Both TuneD profile data and dataFrom.
+			tuned: Tuned{
+				ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+				Spec: TunedSpec{
+					Profile: []TunedProfile{
+						{
+							Name:     ptr.To("custom"),
+							Data:     ptr.To("[main]"),
+							DataFrom: &TunedProfileDataSource{ConfigMap: "custom", Key: "tuned.conf"},
+						},
+					},
+				},
+			},
+			expectedErrors: []string{"data and dataFrom are mutually exclusive"},
+		},
+		{
+			// Negated match rules without a label, also in the subtree.
+			tuned: Tuned{
+				ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+				Spec: TunedSpec{
+					Recommend: []TunedRecommend{
+						{
+							Profile:  ptr.To("custom"),
+							Priority: ptr.To[uint64](10),
+							Match: []TunedMatch{
+								{
+									Label:  ptr.To("node-role.kubernetes.io/worker"),
+									Negate: ptr.To(true),
+									Match: []TunedMatch{
+										{Type: ptr.To("pod"), Negate: ptr.To(true)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: []string{"spec.recommend[0].match[0].match[0].label: Required value"},
+		},
+		{
+			// Invalid nodeSelector.
+			tuned: Tuned{
+				ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+				Spec: TunedSpec{
+					Recommend: []TunedRecommend{
+						{
+							Profile:  ptr.To("custom"),
+							Priority: ptr.To[uint64](10),
+							NodeSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{Key: "node-role.kubernetes.io/worker", Operator: metav1.LabelSelectorOpIn},
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedErrors: []string{"spec.recommend[0].nodeSelector.matchExpressions[0].values: Required value"},
+		},
+		{
+			// Recommend items with the same priority.
+			tuned:            newValidationTestTuned("custom", nil, 10, 20, 10),
+			expectedWarnings: []string{`same priority 20 as spec.recommend[0] of Tuned "default"`, "spec.recommend[0] and spec.recommend[2] have the same priority 10"},
+		},
+	}
+
+	for i, tc := range tests {
+		warnings, errs := tc.tuned.validate(others)
+
+		if len(errs) != len(tc.expectedErrors) {
+			t.Errorf("failed test case %d: want %d error(s), have: %v", i+1, len(tc.expectedErrors), errs)
+			continue
+		}
+		for j, expected := range tc.expectedErrors {
+			if !strings.Contains(errs[j].Error(), expected) {
+				t.Errorf("failed test case %d:\n\twant error containing: %s\n\thave: %s", i+1, expected, errs[j].Error())
+			}
+		}
+		if len(warnings) != len(tc.expectedWarnings) {
+			t.Errorf("failed test case %d: want %d warning(s), have: %v", i+1, len(tc.expectedWarnings), warnings)
+			continue
+		}
+		for j, expected := range tc.expectedWarnings {
+			if !strings.Contains(warnings[j], expected) {
+				t.Errorf("failed test case %d:\n\twant warning containing: %s\n\thave: %s", i+1, expected, warnings[j])
+			}
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package v1
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+var _ webhook.Validator = &Tuned{}
+
+// we need this variable only because our validate methods should have access to the client
+var validatorClient client.Client
+
+// SetupWebhookWithManager enables the Tuned validating webhook.
+func (r *Tuned) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if validatorClient == nil {
+		validatorClient = mgr.GetClient()
+	}
+
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
@@ -21,13 +21,69 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootcmdlineConflict) DeepCopyInto(out *BootcmdlineConflict) {
+	*out = *in
+	if in.Variants != nil {
+		in, out := &in.Variants, &out.Variants
+		*out = make([]BootcmdlineVariant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootcmdlineConflict.
+func (in *BootcmdlineConflict) DeepCopy() *BootcmdlineConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(BootcmdlineConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootcmdlineVariant) DeepCopyInto(out *BootcmdlineVariant) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Recommend != nil {
+		in, out := &in.Recommend, &out.Recommend
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootcmdlineVariant.
+func (in *BootcmdlineVariant) DeepCopy() *BootcmdlineVariant {
+	if in == nil {
+		return nil
+	}
+	out := new(BootcmdlineVariant)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperandConfig) DeepCopyInto(out *OperandConfig) {
 	*out = *in
 	in.TuneDConfig.DeepCopyInto(&out.TuneDConfig)
+	if in.VerifyInterval != nil {
+		in, out := &in.VerifyInterval, &out.VerifyInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -73,6 +129,11 @@ func (in *Profile) DeepCopyObject() runtime.Object {
 func (in *ProfileConfig) DeepCopyInto(out *ProfileConfig) {
 	*out = *in
 	in.TuneDConfig.DeepCopyInto(&out.TuneDConfig)
+	if in.VerifyInterval != nil {
+		in, out := &in.VerifyInterval, &out.VerifyInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -86,6 +147,27 @@ func (in *ProfileConfig) DeepCopy() *ProfileConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileDeferredUpdate) DeepCopyInto(out *ProfileDeferredUpdate) {
+	*out = *in
+	if in.Diff != nil {
+		in, out := &in.Diff, &out.Diff
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileDeferredUpdate.
+func (in *ProfileDeferredUpdate) DeepCopy() *ProfileDeferredUpdate {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileDeferredUpdate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProfileList) DeepCopyInto(out *ProfileList) {
 	*out = *in
@@ -119,6 +201,32 @@ func (in *ProfileList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProfileSelection) DeepCopyInto(out *ProfileSelection) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProfileSelection.
+func (in *ProfileSelection) DeepCopy() *ProfileSelection {
+	if in == nil {
+		return nil
+	}
+	out := new(ProfileSelection)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProfileSpec) DeepCopyInto(out *ProfileSpec) {
 	*out = *in
@@ -153,6 +261,26 @@ func (in *ProfileStatus) DeepCopyInto(out *ProfileStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DeferredUpdate != nil {
+		in, out := &in.DeferredUpdate, &out.DeferredUpdate
+		*out = new(ProfileDeferredUpdate)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootcmdlineConflict != nil {
+		in, out := &in.BootcmdlineConflict, &out.BootcmdlineConflict
+		*out = new(BootcmdlineConflict)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MissingNodeVariables != nil {
+		in, out := &in.MissingNodeVariables, &out.MissingNodeVariables
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SelectedBy != nil {
+		in, out := &in.SelectedBy, &out.SelectedBy
+		*out = new(ProfileSelection)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -183,6 +311,37 @@ func (in *ProfileStatusCondition) DeepCopy() *ProfileStatusCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutStrategy) DeepCopyInto(out *RolloutStrategy) {
+	*out = *in
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.PauseOnDegraded != nil {
+		in, out := &in.PauseOnDegraded, &out.PauseOnDegraded
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutStrategy.
+func (in *RolloutStrategy) DeepCopy() *RolloutStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TuneDConfig) DeepCopyInto(out *TuneDConfig) {
 	*out = *in
@@ -210,7 +369,7 @@ func (in *Tuned) DeepCopyInto(out *Tuned) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -283,6 +442,11 @@ func (in *TunedMatch) DeepCopyInto(out *TunedMatch) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Negate != nil {
+		in, out := &in.Negate, &out.Negate
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Match != nil {
 		in, out := &in.Match, &out.Match
 		*out = make([]TunedMatch, len(*in))
@@ -303,6 +467,48 @@ func (in *TunedMatch) DeepCopy() *TunedMatch {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunedPreview) DeepCopyInto(out *TunedPreview) {
+	*out = *in
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = make([]TunedPreviewNode, len(*in))
+		copy(*out, *in)
+	}
+	if in.MachineConfigPools != nil {
+		in, out := &in.MachineConfigPools, &out.MachineConfigPools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunedPreview.
+func (in *TunedPreview) DeepCopy() *TunedPreview {
+	if in == nil {
+		return nil
+	}
+	out := new(TunedPreview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunedPreviewNode) DeepCopyInto(out *TunedPreviewNode) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunedPreviewNode.
+func (in *TunedPreviewNode) DeepCopy() *TunedPreviewNode {
+	if in == nil {
+		return nil
+	}
+	out := new(TunedPreviewNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TunedProfile) DeepCopyInto(out *TunedProfile) {
 	*out = *in
@@ -316,6 +522,11 @@ func (in *TunedProfile) DeepCopyInto(out *TunedProfile) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.DataFrom != nil {
+		in, out := &in.DataFrom, &out.DataFrom
+		*out = new(TunedProfileDataSource)
+		**out = **in
+	}
 	return
 }
 
@@ -329,6 +540,22 @@ func (in *TunedProfile) DeepCopy() *TunedProfile {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunedProfileDataSource) DeepCopyInto(out *TunedProfileDataSource) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunedProfileDataSource.
+func (in *TunedProfileDataSource) DeepCopy() *TunedProfileDataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(TunedProfileDataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TunedRecommend) DeepCopyInto(out *TunedRecommend) {
 	*out = *in
@@ -349,6 +576,11 @@ func (in *TunedRecommend) DeepCopyInto(out *TunedRecommend) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.MachineConfigLabels != nil {
 		in, out := &in.MachineConfigLabels, &out.MachineConfigLabels
 		*out = make(map[string]string, len(*in))
@@ -357,6 +589,11 @@ func (in *TunedRecommend) DeepCopyInto(out *TunedRecommend) {
 		}
 	}
 	in.Operand.DeepCopyInto(&out.Operand)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(RolloutStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -370,6 +607,32 @@ func (in *TunedRecommend) DeepCopy() *TunedRecommend {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunedRecommendStatus) DeepCopyInto(out *TunedRecommendStatus) {
+	*out = *in
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(uint64)
+		**out = **in
+	}
+	if in.UpdatedNodes != nil {
+		in, out := &in.UpdatedNodes, &out.UpdatedNodes
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunedRecommendStatus.
+func (in *TunedRecommendStatus) DeepCopy() *TunedRecommendStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TunedRecommendStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TunedSpec) DeepCopyInto(out *TunedSpec) {
 	*out = *in
@@ -403,6 +666,32 @@ func (in *TunedSpec) DeepCopy() *TunedSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TunedStatus) DeepCopyInto(out *TunedStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]TunedStatusCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Recommend != nil {
+		in, out := &in.Recommend, &out.Recommend
+		*out = make([]TunedRecommendStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Preview != nil {
+		in, out := &in.Preview, &out.Preview
+		*out = new(TunedPreview)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BootcmdlineConflicts != nil {
+		in, out := &in.BootcmdlineConflicts, &out.BootcmdlineConflicts
+		*out = make([]BootcmdlineConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -415,3 +704,20 @@ func (in *TunedStatus) DeepCopy() *TunedStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TunedStatusCondition) DeepCopyInto(out *TunedStatusCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TunedStatusCondition.
+func (in *TunedStatusCondition) DeepCopy() *TunedStatusCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(TunedStatusCondition)
+	in.DeepCopyInto(out)
+	return out
+}
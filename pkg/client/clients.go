@@ -18,6 +18,6 @@ type Clients struct {
 	Tuned           *tunedset.Clientset
 	MC              *mcfgclientset.Clientset
 	Core            *coreset.CoreV1Client
-	Apps            *appsset.AppsV1Client
+	Apps            appsset.AppsV1Interface
 	ManagementKube  *kubeset.Clientset
 }
@@ -0,0 +1,48 @@
+package client
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/tools/record"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+// NewEventRecorder returns an EventRecorder recording Events on core and
+// tuned.openshift.io objects on behalf of component 'component' running on
+// host 'host' (optional).
+func NewEventRecorder(kubeClient kubernetes.Interface, component, host string) record.EventRecorder {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(tunedv1.AddToScheme(scheme))
+
+	broadcaster := record.NewBroadcaster()
+	broadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: kubeClient.CoreV1().Events("")})
+
+	return broadcaster.NewRecorder(scheme, corev1.EventSource{Component: component, Host: host})
+}
+
+// nodeReference returns a reference to Node 'nodeName' the way the kubelet
+// references Nodes in Events, so that the Events show up in Node descriptions.
+func nodeReference(nodeName string) *corev1.ObjectReference {
+	return &corev1.ObjectReference{
+		Kind: "Node",
+		Name: nodeName,
+		UID:  types.UID(nodeName),
+	}
+}
+
+// RecordProfileEvent records an Event on Profile 'profile' and the Node
+// of the same name.
+func RecordProfileEvent(recorder record.EventRecorder, profile *tunedv1.Profile, eventtype, reason, message string) {
+	if recorder == nil || profile == nil {
+		return
+	}
+	recorder.Event(profile, eventtype, reason, message)
+	recorder.Event(nodeReference(profile.Name), eventtype, reason, message)
+}
@@ -11,14 +11,16 @@ import (
 )
 
 type Listers struct {
-	DaemonSets         kappslisters.DaemonSetNamespaceLister
-	ConfigMaps         kcorelisters.ConfigMapNamespaceLister
-	AuthConfigMapCA    kcorelisters.ConfigMapNamespaceLister
-	Pods               kcorelisters.PodLister
-	Nodes              kcorelisters.NodeLister
-	ClusterOperators   configlisters.ClusterOperatorLister
-	TunedResources     ntolisters.TunedNamespaceLister
-	TunedProfiles      ntolisters.ProfileNamespaceLister
-	MachineConfigs     mcfglisters.MachineConfigLister
-	MachineConfigPools mcfglisters.MachineConfigPoolLister
+	DaemonSets          kappslisters.DaemonSetNamespaceLister
+	ControllerRevisions kappslisters.ControllerRevisionNamespaceLister
+	ConfigMaps          kcorelisters.ConfigMapNamespaceLister
+	AuthConfigMapCA     kcorelisters.ConfigMapNamespaceLister
+	TunedConfigMaps     kcorelisters.ConfigMapNamespaceLister
+	Pods                kcorelisters.PodLister
+	Nodes               kcorelisters.NodeLister
+	ClusterOperators    configlisters.ClusterOperatorLister
+	TunedResources      ntolisters.TunedNamespaceLister
+	TunedProfiles       ntolisters.ProfileNamespaceLister
+	MachineConfigs      mcfglisters.MachineConfigLister
+	MachineConfigPools  mcfglisters.MachineConfigPoolLister
 }
@@ -1,6 +1,11 @@
 package metrics
 
-import "github.com/prometheus/client_golang/prometheus"
+import (
+	"strconv"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
 
 // When adding metric names, see https://prometheus.io/docs/practices/naming/#metric-names
 const (
@@ -8,6 +13,18 @@ const (
 	profileCalculatedQuery = "nto_profile_calculated_total"
 	buildInfoQuery         = "nto_build_info"
 	degradedInfoQuery      = "nto_degraded_info"
+	profileAppliedQuery    = "nto_profile_applied_info"
+	profileDegradedQuery   = "nto_profile_degraded_info"
+	profileProgressQuery   = "nto_profile_progressing_info"
+	profileActiveQuery     = "nto_profile_active_info"
+	profileConflictQuery   = "nto_profile_bootcmdline_conflict_info"
+	machineConfigSyncQuery = "nto_machine_config_syncs_total"
+	recommendUnusedQuery   = "nto_tuned_recommend_unused_info"
+
+	// MachineConfig synchronization results.
+	MachineConfigCreated = "created"
+	MachineConfigUpdated = "updated"
+	MachineConfigFailed  = "failed"
 
 	// MetricsPort is the IP port supplied to the HTTP server used for Prometheus,
 	// and matches what is specified in the corresponding Service and ServiceMonitor.
@@ -42,17 +59,106 @@ var (
 			Help: "Indicates whether the Node Tuning Operator is degraded.",
 		},
 	)
+	profileApplied = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: profileAppliedQuery,
+			Help: "Indicates whether the TuneD profile of a given node's Profile has been applied.",
+		},
+		[]string{"node"},
+	)
+	profileDegraded = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: profileDegradedQuery,
+			Help: "Indicates whether a given node's Profile is degraded.",
+		},
+		[]string{"node"},
+	)
+	profileProgressing = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: profileProgressQuery,
+			Help: "Indicates whether a given node's Profile is waiting to be applied.",
+		},
+		[]string{"node"},
+	)
+	profileActive = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: profileActiveQuery,
+			Help: "A metric with a constant '1' value labeled by node and the TuneD profile reported as active on the node.",
+		},
+		[]string{"node", "profile"},
+	)
+	profileBootcmdlineConflict = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: profileConflictQuery,
+			Help: "Indicates whether a given node's kernel command-line conflicts with other nodes in its MachineConfigPool.",
+		},
+		[]string{"node"},
+	)
+	machineConfigSyncs = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: machineConfigSyncQuery,
+			Help: "The number of MachineConfig synchronizations by MachineConfig name and result (created, updated or failed).",
+		},
+		[]string{"name", "result"},
+	)
+	recommendUnused = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: recommendUnusedQuery,
+			Help: "A metric with a constant '1' value labeled by Tuned, recommend item index and the reason (Unreachable or NoMatch) the recommend item selected no node.",
+		},
+		[]string{"tuned", "recommend", "reason"},
+	)
+
+	// profileNodes holds the active TuneD profile of nodes with Profile metrics.
+	profileNodes      = map[string]string{}
+	profileNodesMutex sync.Mutex
+
+	// recommendUnusedLabels holds the labels of the recommend item metrics set.
+	recommendUnusedLabels      = map[RecommendUnused]bool{}
+	recommendUnusedLabelsMutex sync.Mutex
 )
 
+// ProfileState is the state of a single node's Profile exposed in metrics.
+type ProfileState struct {
+	Node                string
+	TunedProfile        string // TuneD profile reported as active by the operand
+	Applied             bool
+	Degraded            bool
+	Progressing         bool
+	BootcmdlineConflict bool
+}
+
+// RecommendUnused is a recommend item of a Tuned object which selected no node
+// exposed in metrics.
+type RecommendUnused struct {
+	Tuned     string
+	Recommend int
+	Reason    string
+}
+
 func init() {
 	registry.MustRegister(
 		podLabelsUsed,
 		profileCalculated,
 		buildInfo,
 		degradedState,
+		profileApplied,
+		profileDegraded,
+		profileProgressing,
+		profileActive,
+		profileBootcmdlineConflict,
+		machineConfigSyncs,
+		recommendUnused,
 	)
 }
 
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // PodLabelsUsed indicates whether the deprecated Pod label matching functionality
 // is turned on.
 func PodLabelsUsed(enable bool) {
@@ -83,3 +189,70 @@ func Degraded(deg bool) {
 	}
 	degradedState.Set(0)
 }
+
+// ProfileStates sets the per-node Profile metrics to 'states'.  Metrics of nodes
+// not found in 'states' are removed.
+func ProfileStates(states []ProfileState) {
+	profileNodesMutex.Lock()
+	defer profileNodesMutex.Unlock()
+
+	seen := map[string]bool{}
+	for _, state := range states {
+		seen[state.Node] = true
+		labels := prometheus.Labels{"node": state.Node}
+
+		profileApplied.With(labels).Set(boolToFloat64(state.Applied))
+		profileDegraded.With(labels).Set(boolToFloat64(state.Degraded))
+		profileProgressing.With(labels).Set(boolToFloat64(state.Progressing))
+		profileBootcmdlineConflict.With(labels).Set(boolToFloat64(state.BootcmdlineConflict))
+
+		if tunedProfile, ok := profileNodes[state.Node]; ok && tunedProfile != state.TunedProfile {
+			profileActive.Delete(prometheus.Labels{"node": state.Node, "profile": tunedProfile})
+		}
+		profileActive.With(prometheus.Labels{"node": state.Node, "profile": state.TunedProfile}).Set(1)
+		profileNodes[state.Node] = state.TunedProfile
+	}
+
+	for node := range profileNodes {
+		if seen[node] {
+			continue
+		}
+		labels := prometheus.Labels{"node": node}
+		profileApplied.Delete(labels)
+		profileDegraded.Delete(labels)
+		profileProgressing.Delete(labels)
+		profileBootcmdlineConflict.Delete(labels)
+		profileActive.DeletePartialMatch(labels)
+		delete(profileNodes, node)
+	}
+}
+
+// MachineConfigSynced keeps track of the number of MachineConfig 'name'
+// synchronizations with the result 'result'.
+func MachineConfigSynced(name, result string) {
+	machineConfigSyncs.With(map[string]string{"name": name, "result": result}).Inc()
+}
+
+// RecommendsUnused sets the metrics of unused recommend items to 'unused'.  Metrics
+// of recommend items not found in 'unused' are removed.
+func RecommendsUnused(unused []RecommendUnused) {
+	recommendUnusedLabelsMutex.Lock()
+	defer recommendUnusedLabelsMutex.Unlock()
+
+	seen := map[RecommendUnused]bool{}
+	for _, u := range unused {
+		seen[u] = true
+		recommendUnused.With(recommendUnusedPromLabels(u)).Set(1)
+	}
+
+	for u := range recommendUnusedLabels {
+		if !seen[u] {
+			recommendUnused.Delete(recommendUnusedPromLabels(u))
+		}
+	}
+	recommendUnusedLabels = seen
+}
+
+func recommendUnusedPromLabels(u RecommendUnused) prometheus.Labels {
+	return prometheus.Labels{"tuned": u.Tuned, "recommend": strconv.Itoa(u.Recommend), "reason": u.Reason}
+}
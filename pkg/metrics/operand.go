@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// When adding metric names, see https://prometheus.io/docs/practices/naming/#metric-names
+const (
+	tunedRestartsQuery        = "nto_tuned_restarts_total"
+	tunedReloadsQuery         = "nto_tuned_reloads_total"
+	tunedFailuresQuery        = "nto_tuned_failures_total"
+	tunedApplyDurationQuery   = "nto_tuned_profile_apply_duration_seconds"
+	tunedStatusQuery          = "nto_tuned_status_info"
+	tunedDeferredPendingQuery = "nto_tuned_deferred_update_pending_info"
+
+	// OperandMetricsPort is the IP port supplied to the HTTP server used for Prometheus
+	// by the operand.  The operand runs in the host network namespace, the port is
+	// outside of the ephemeral port range.
+	OperandMetricsPort = 9195
+)
+
+var (
+	operandRegistry = prometheus.NewRegistry()
+	tunedRestarts   = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: tunedRestartsQuery,
+			Help: "The number of times the TuneD daemon was (re)started.",
+		},
+	)
+	tunedReloads = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: tunedReloadsQuery,
+			Help: "The number of times the TuneD daemon was reloaded.",
+		},
+	)
+	tunedFailures = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: tunedFailuresQuery,
+			Help: "The number of times the TuneD daemon reported errors applying a profile or terminated unexpectedly.",
+		},
+	)
+	tunedApplyDuration = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    tunedApplyDurationQuery,
+			Help:    "The time it took the TuneD daemon to apply a profile after a reload or restart.",
+			Buckets: []float64{1, 2, 5, 10, 20, 30, 60, 120, 300},
+		},
+	)
+	tunedStatus = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: tunedStatusQuery,
+			Help: "Indicates whether the given status bit of the TuneD daemon is set.",
+		},
+		[]string{"status"},
+	)
+	tunedDeferredPending = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: tunedDeferredPendingQuery,
+			Help: "Indicates whether a deferred update is pending on the node.",
+		},
+	)
+)
+
+func init() {
+	operandRegistry.MustRegister(
+		tunedRestarts,
+		tunedReloads,
+		tunedFailures,
+		tunedApplyDuration,
+		tunedStatus,
+		tunedDeferredPending,
+	)
+}
+
+// TunedRestarted keeps track of the number of TuneD daemon (re)starts.
+func TunedRestarted() {
+	tunedRestarts.Inc()
+}
+
+// TunedReloaded keeps track of the number of TuneD daemon reloads.
+func TunedReloaded() {
+	tunedReloads.Inc()
+}
+
+// TunedFailed keeps track of the number of TuneD daemon failures.
+func TunedFailed() {
+	tunedFailures.Inc()
+}
+
+// TunedProfileApplied records the time 'seconds' it took the TuneD daemon
+// to apply a profile.
+func TunedProfileApplied(seconds float64) {
+	tunedApplyDuration.Observe(seconds)
+}
+
+// TunedStatus sets the TuneD daemon status bits.  'status' maps the status
+// bit names to whether they are set.
+func TunedStatus(status map[string]bool) {
+	for name, set := range status {
+		tunedStatus.WithLabelValues(name).Set(boolToFloat64(set))
+	}
+}
+
+// TunedDeferredUpdatePending sets the metric that indicates whether a deferred
+// update is pending on the node.
+func TunedDeferredUpdatePending(pending bool) {
+	tunedDeferredPending.Set(boolToFloat64(pending))
+}
@@ -0,0 +1,164 @@
+package metrics
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// newTestCABundle returns a PEM-encoded self-signed CA certificate with common name 'cn'.
+func newTestCABundle(t *testing.T, cn string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestTunedStatus(t *testing.T) {
+	tests := []struct {
+		status   map[string]bool
+		pending  bool
+		expected map[string]float64
+	}{
+		{
+			status:   map[string]bool{"applied": true, "warn": false, "error": false},
+			expected: map[string]float64{"applied": 1, "warn": 0, "error": 0},
+		},
+		{
+			status:   map[string]bool{"applied": false, "warn": true, "error": true},
+			pending:  true,
+			expected: map[string]float64{"applied": 0, "warn": 1, "error": 1},
+		},
+	}
+
+	for i, tc := range tests {
+		TunedStatus(tc.status)
+		TunedDeferredUpdatePending(tc.pending)
+
+		for name, want := range tc.expected {
+			if have := testutil.ToFloat64(tunedStatus.WithLabelValues(name)); have != want {
+				t.Errorf("failed test case %d: status %s:\n\twant: %v\n\thave: %v", i+1, name, want, have)
+			}
+		}
+		if have := testutil.ToFloat64(tunedDeferredPending); have != boolToFloat64(tc.pending) {
+			t.Errorf("failed test case %d: deferred update pending:\n\twant: %v\n\thave: %v", i+1, boolToFloat64(tc.pending), have)
+		}
+	}
+}
+
+func TestUpdateCA(t *testing.T) {
+	ca := newTestCABundle(t, "ca")
+
+	tests := []struct {
+		caBundle string
+		canceled bool
+		sent     bool
+	}{
+		{
+			// Valid root certificate bundle.
+			caBundle: ca,
+			sent:     true,
+		},
+		{
+			// Bundles failing to parse are dropped.
+			caBundle: "not a certificate",
+		},
+		{
+			// Nobody receives the bundle once the server stops.
+			caBundle: ca,
+			canceled: true,
+		},
+	}
+
+	for i, tc := range tests {
+		s := NewOperandServer()
+		ctx, cancel := context.WithCancel(context.Background())
+		received := make(chan string, 1)
+		if tc.canceled {
+			cancel()
+		} else {
+			go func() {
+				select {
+				case caBundle := <-s.caBundleCh:
+					received <- caBundle
+				case <-ctx.Done():
+				}
+			}()
+		}
+
+		s.UpdateCA(ctx, tc.caBundle)
+
+		var have string
+		select {
+		case have = <-received:
+		case <-time.After(100 * time.Millisecond):
+		}
+		cancel()
+		if sent := have != ""; sent != tc.sent {
+			t.Errorf("failed test case %d:\n\twant sent: %v\n\thave: %v", i+1, tc.sent, sent)
+		}
+		if tc.sent && have != tc.caBundle {
+			t.Errorf("failed test case %d:\n\twant: %q\n\thave: %q", i+1, tc.caBundle, have)
+		}
+	}
+}
+
+func TestServerRunCAChange(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	s := NewOperandServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.run(ctx, port, prometheus.NewRegistry())
+	}()
+
+	// The first bundle starts the server, the following ones restart it.
+	bundles := []string{newTestCABundle(t, "ca-1"), newTestCABundle(t, "ca-2"), "not a certificate"}
+	for _, caBundle := range bundles {
+		s.UpdateCA(ctx, caBundle)
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("failed to run the metrics server: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("metrics server did not stop")
+	}
+	if s.caBundle != bundles[1] {
+		t.Errorf("want the last valid root certificate bundle in use, have: %q", s.caBundle)
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	"k8s.io/klog/v2"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"gopkg.in/fsnotify.v1"
 )
@@ -51,14 +52,14 @@ func DumpCA(caBundle string) {
 	}
 }
 
-func buildServer(port int, caBundle string) *http.Server {
+func buildServer(port int, caBundle string, gatherer prometheus.Gatherer) *http.Server {
 	if port <= 0 {
 		klog.Error("invalid port for metric server")
 		return nil
 	}
 
 	handler := promhttp.HandlerFor(
-		registry,
+		gatherer,
 		promhttp.HandlerOpts{
 			ErrorHandling: promhttp.HTTPErrorOnError,
 		},
@@ -125,6 +126,40 @@ func (Server) Start(ctx context.Context) error {
 // and restarted with the current files.  Every non-nil return from this function is fatal
 // and will restart the whole operator.
 func RunServer(port int, ctx context.Context) error {
+	return server.run(ctx, port, registry)
+}
+
+// NewOperandServer returns the operand metrics server.  The server is started
+// by RunOperand once it receives the first root certificate bundle via UpdateCA.
+func NewOperandServer() *Server {
+	return &Server{caBundleCh: make(chan string)}
+}
+
+// UpdateCA sends the root certificate bundle used to verify client certificates
+// to the operand metrics server 's', which restarts the server.  Bundles which
+// fail to parse are dropped, so that metrics are never served without client
+// authentication.
+func (s *Server) UpdateCA(ctx context.Context, caBundle string) {
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(caBundle)) {
+		klog.Errorf("failed to parse root certificate bundle of the metrics server, keeping the current one")
+		return
+	}
+
+	select {
+	case s.caBundleCh <- caBundle:
+	case <-ctx.Done():
+	}
+}
+
+// RunOperand starts the operand metrics server the same way as RunServer.
+func (s *Server) RunOperand(ctx context.Context) error {
+	return s.run(ctx, OperandMetricsPort, operandRegistry)
+}
+
+// run starts the server exposing metrics from 'gatherer' on port 'port' and restarts
+// it on certificate/key and root certificate bundle changes.  If the server has a
+// channel to receive the root certificate bundle, it waits for the bundle first.
+func (s *Server) run(ctx context.Context, port int, gatherer prometheus.Gatherer) error {
 	// Set up and start the file watcher.
 	watcher, err := fsnotify.NewWatcher()
 	if watcher == nil || err != nil {
@@ -135,13 +170,19 @@ func RunServer(port int, ctx context.Context) error {
 		if err = watcher.Add(tlsSecretDir); err != nil {
 			klog.Errorf("failed to add %v to watcher, cert/key rotation will be disabled: %v", tlsSecretDir, err)
 		}
+	}
 
+	if s.caBundleCh != nil {
 		// Wait for the root certificate bundle of the metrics server for client authentication.
-		// The bundle is sent from a ConfigMap via a channel by the operator.
-		server.caBundle = <-server.caBundleCh
+		// The bundle is sent from a ConfigMap via a channel by the operator or the operand.
+		select {
+		case s.caBundle = <-s.caBundleCh:
+		case <-ctx.Done():
+			return nil
+		}
 	}
 
-	srv := buildServer(port, server.caBundle)
+	srv := buildServer(port, s.caBundle, gatherer)
 	if srv == nil {
 		return fmt.Errorf("failed to build server with port %d", port)
 	}
@@ -157,7 +198,7 @@ func RunServer(port int, ctx context.Context) error {
 		case <-ctx.Done():
 			stopServer(srv)
 			return nil
-		case server.caBundle = <-server.caBundleCh:
+		case s.caBundle = <-s.caBundleCh:
 			restartServer = true
 		case event := <-watcher.Events:
 			klog.V(2).Infof("event from filewatcher on file: %v, event: %v", event.Name, event.Op)
@@ -180,7 +221,7 @@ func RunServer(port int, ctx context.Context) error {
 			// Restart the metrics server.
 			klog.Infof("restarting metrics server to rotate certificates")
 			stopServer(srv)
-			srv = buildServer(port, server.caBundle)
+			srv = buildServer(port, s.caBundle, gatherer)
 			go startServer(srv)
 		}
 	}
@@ -0,0 +1,137 @@
+package operator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+)
+
+// usesMachineConfig returns true if the kernel arguments calculated by TuneD for
+// profile 'computed' are synced to the MachineConfig of a pool.
+func usesMachineConfig(computed ComputedProfile) bool {
+	if ntoconfig.InHyperShift() {
+		return computed.NodePoolName != ""
+	}
+	return computed.MCLabels != nil
+}
+
+// computeBootcmdlineConflict returns the report of Nodes 'nodes' of pool 'pool'
+// disagreeing on the kernel arguments 'bootcmdline' (indexed by Node name) calculated
+// by TuneD, or nil if they all agree.  'selected' are the recommend items which selected
+// the TuneD profiles for the individual Nodes (indexed by Node name).
+func computeBootcmdlineConflict(pool string, nodes []*corev1.Node, bootcmdline map[string]string, selected map[string]TunedRecommendSource) *tunedv1.BootcmdlineConflict {
+	variants := map[string]*tunedv1.BootcmdlineVariant{}
+	recommend := map[string]map[string]bool{}
+
+	for _, node := range nodes {
+		nodeName := node.ObjectMeta.Name
+		cmdline := bootcmdline[nodeName]
+		variant, ok := variants[cmdline]
+		if !ok {
+			variant = &tunedv1.BootcmdlineVariant{Bootcmdline: cmdline}
+			variants[cmdline] = variant
+			recommend[cmdline] = map[string]bool{}
+		}
+		variant.Nodes = append(variant.Nodes, nodeName)
+		if source, ok := selected[nodeName]; ok && source.TunedName != "" {
+			recommend[cmdline][source.String()] = true
+		}
+	}
+
+	if len(variants) <= 1 {
+		return nil
+	}
+
+	conflict := &tunedv1.BootcmdlineConflict{Pool: pool}
+	for cmdline, variant := range variants {
+		sort.Strings(variant.Nodes)
+		for source := range recommend[cmdline] {
+			variant.Recommend = append(variant.Recommend, source)
+		}
+		sort.Strings(variant.Recommend)
+		conflict.Variants = append(conflict.Variants, *variant)
+	}
+	sort.Slice(conflict.Variants, func(i, j int) bool {
+		return conflict.Variants[i].Bootcmdline < conflict.Variants[j].Bootcmdline
+	})
+
+	return conflict
+}
+
+// syncBootcmdlineConflict records the report 'conflict' of the Nodes 'nodes' of pool
+// 'pool' disagreeing on the kernel arguments calculated by TuneD (nil if they agree)
+// in the pool summary and in the status of the Profiles of the Nodes.
+func (c *Controller) syncBootcmdlineConflict(pool string, nodes []*corev1.Node, conflict *tunedv1.BootcmdlineConflict) error {
+	var lastErr error
+
+	if !reflect.DeepEqual(c.poolBootcmdlineConflict[pool], conflict) {
+		if conflict == nil {
+			klog.Infof("Nodes in pool %s agree on bootcmdline", pool)
+			delete(c.poolBootcmdlineConflict, pool)
+		} else {
+			c.poolBootcmdlineConflict[pool] = conflict
+		}
+		c.enqueueTunedStatusUpdate()
+	}
+
+	for _, node := range nodes {
+		profile, err := c.listers.TunedProfiles.Get(node.ObjectMeta.Name)
+		if err != nil {
+			if !errors.IsNotFound(err) {
+				lastErr = fmt.Errorf("failed to get Profile %s: %v", node.ObjectMeta.Name, err)
+			}
+			continue
+		}
+		err = c.updateProfileStatus(profile, func(status *tunedv1.ProfileStatus) {
+			status.BootcmdlineConflict = conflict
+		})
+		if err != nil {
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// tunedBootcmdlineConflicts returns the reports from 'conflicts' (indexed by pool name)
+// with a variant of the kernel arguments coming from a recommend item of Tuned 'tuned'.
+func tunedBootcmdlineConflicts(tuned *tunedv1.Tuned, conflicts map[string]*tunedv1.BootcmdlineConflict) []tunedv1.BootcmdlineConflict {
+	var ret []tunedv1.BootcmdlineConflict
+
+	prefix := tuned.Name + "/recommend["
+	for _, conflict := range conflicts {
+	variants:
+		for _, variant := range conflict.Variants {
+			for _, recommend := range variant.Recommend {
+				if strings.HasPrefix(recommend, prefix) {
+					ret = append(ret, *conflict)
+					break variants
+				}
+			}
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Pool < ret[j].Pool
+	})
+
+	return ret
+}
+
+// bootcmdlineConflictPools returns the sorted names of the pools in 'conflicts'.
+func bootcmdlineConflictPools(conflicts map[string]*tunedv1.BootcmdlineConflict) []string {
+	pools := make([]string, 0, len(conflicts))
+	for pool := range conflicts {
+		pools = append(pools, pool)
+	}
+	sort.Strings(pools)
+
+	return pools
+}
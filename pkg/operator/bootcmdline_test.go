@@ -0,0 +1,128 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func newTestNodes(names ...string) []*corev1.Node {
+	nodes := make([]*corev1.Node, 0, len(names))
+	for _, name := range names {
+		nodes = append(nodes, &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	return nodes
+}
+
+func TestComputeBootcmdlineConflict(t *testing.T) {
+	selected := map[string]TunedRecommendSource{
+		"node1": {TunedName: "default", Index: 0},
+		"node2": {TunedName: "rt", Index: 1},
+		"node3": {TunedName: "rt", Index: 1},
+		"node4": {TunedName: "rt", Index: 0},
+	}
+
+	tests := []struct {
+		nodes       []*corev1.Node
+		bootcmdline map[string]string
+		expected    *tunedv1.BootcmdlineConflict
+	}{
+		{
+			nodes:    nil,
+			expected: nil,
+		},
+		{
+			nodes: newTestNodes("node1", "node2"),
+			bootcmdline: map[string]string{
+				"node1": "skew_tick=1",
+				"node2": "skew_tick=1",
+			},
+			expected: nil,
+		},
+		{
+			nodes: newTestNodes("node3", "node1", "node2", "node4"),
+			bootcmdline: map[string]string{
+				"node2": "skew_tick=1 nohz=on",
+				"node3": "skew_tick=1 nohz=on",
+				"node4": "skew_tick=1",
+			},
+			expected: &tunedv1.BootcmdlineConflict{
+				Pool: "worker",
+				Variants: []tunedv1.BootcmdlineVariant{
+					{
+						Bootcmdline: "",
+						Nodes:       []string{"node1"},
+						Recommend:   []string{"default/recommend[0]"},
+					},
+					{
+						Bootcmdline: "skew_tick=1",
+						Nodes:       []string{"node4"},
+						Recommend:   []string{"rt/recommend[0]"},
+					},
+					{
+						Bootcmdline: "skew_tick=1 nohz=on",
+						Nodes:       []string{"node2", "node3"},
+						Recommend:   []string{"rt/recommend[1]"},
+					},
+				},
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		have := computeBootcmdlineConflict("worker", tc.nodes, tc.bootcmdline, selected)
+		if !reflect.DeepEqual(have, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %+v\n\thave: %+v", i, tc.expected, have)
+		}
+	}
+}
+
+func TestTunedBootcmdlineConflicts(t *testing.T) {
+	conflictWorker := &tunedv1.BootcmdlineConflict{
+		Pool: "worker",
+		Variants: []tunedv1.BootcmdlineVariant{
+			{Bootcmdline: "", Nodes: []string{"node1"}, Recommend: []string{"default/recommend[0]"}},
+			{Bootcmdline: "skew_tick=1", Nodes: []string{"node2"}, Recommend: []string{"rt/recommend[0]"}},
+		},
+	}
+	conflictInfra := &tunedv1.BootcmdlineConflict{
+		Pool: "infra",
+		Variants: []tunedv1.BootcmdlineVariant{
+			{Bootcmdline: "", Nodes: []string{"node3"}, Recommend: []string{"default/recommend[0]"}},
+			{Bootcmdline: "nosmt", Nodes: []string{"node4"}, Recommend: []string{"rt-other/recommend[0]"}},
+		},
+	}
+	conflicts := map[string]*tunedv1.BootcmdlineConflict{
+		"worker": conflictWorker,
+		"infra":  conflictInfra,
+	}
+
+	tests := []struct {
+		tuned    *tunedv1.Tuned
+		expected []tunedv1.BootcmdlineConflict
+	}{
+		{
+			tuned:    newTestTuned("default", nil, nil),
+			expected: []tunedv1.BootcmdlineConflict{*conflictInfra, *conflictWorker},
+		},
+		{
+			tuned:    newTestTuned("rt", nil, nil),
+			expected: []tunedv1.BootcmdlineConflict{*conflictWorker},
+		},
+		{
+			tuned:    newTestTuned("unrelated", nil, nil),
+			expected: nil,
+		},
+	}
+
+	for i, tc := range tests {
+		have := tunedBootcmdlineConflicts(tc.tuned, conflicts)
+		if !reflect.DeepEqual(have, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %+v\n\thave: %+v", i, tc.expected, have)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"strings"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -22,6 +23,7 @@ import (
 	coreset "k8s.io/client-go/kubernetes/typed/core/v1"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
@@ -60,7 +62,9 @@ const (
 	wqKindTuned             = "tuned"
 	wqKindProfile           = "profile"
 	wqKindConfigMap         = "configmap"
+	wqKindTunedConfigMap    = "tunedconfigmap"
 	wqKindMachineConfigPool = "machineconfigpool"
+	wqKindTunedStatus       = "tunedstatus"
 )
 
 // Controller is the controller implementation for Tuned resources
@@ -74,6 +78,9 @@ type Controller struct {
 	listers *ntoclient.Listers
 	clients *ntoclient.Clients
 
+	// recorder records Events on Profile and Node objects.
+	recorder record.EventRecorder
+
 	pod, node struct {
 		informerEnabled bool
 		stopCh          chan struct{}
@@ -87,6 +94,40 @@ type Controller struct {
 	// tracked as having kernel command-line conflict due to belonging
 	// to the same MCP.
 	bootcmdlineConflict map[string]bool
+
+	// poolBootcmdlineConflict is the internal operator's cache of reports of
+	// MachineConfigPools (or NodePools on HyperShift) whose Nodes disagree on
+	// the kernel command-line (indexed by pool name).
+	poolBootcmdlineConflict map[string]*tunedv1.BootcmdlineConflict
+
+	// recommendSelected is the internal operator's cache of Tuned recommend
+	// items selected for the individual Nodes (indexed by Node name).
+	recommendSelected map[string]TunedRecommendSource
+
+	// priorityConflicts is the internal operator's cache of recommend items
+	// matching the individual Nodes with the same priority, but different TuneD
+	// profiles (indexed by Node name).
+	priorityConflicts map[string][]TunedRecommendSource
+
+	// profilesSynced is set once every Profile was synced at least once since
+	// the operator started, i.e. recommendSelected is complete.
+	profilesSynced bool
+
+	// rollouts is the internal operator's cache of rollout states of Tuned
+	// recommend items with a rollout strategy.
+	rollouts map[TunedRecommendSource]rolloutState
+
+	// rolloutUpdated is the internal operator's cache of Profiles updated
+	// by a rollout (indexed by Node name) not yet seen by the informer.
+	rolloutUpdated map[string]*tunedv1.Profile
+
+	// rolloutWaves is the internal operator's cache of the number of Profiles
+	// updated in the current rollout wave (indexed by recommend item).
+	rolloutWaves map[TunedRecommendSource]int
+
+	// tunedRevisions is the internal operator's cache of the names of
+	// ControllerRevisions recorded for Tuned objects (indexed by Tuned name).
+	tunedRevisions map[string]string
 }
 
 type wqKey struct {
@@ -118,6 +159,13 @@ func NewController() (*Controller, error) {
 	}
 
 	controller.bootcmdlineConflict = map[string]bool{}
+	controller.poolBootcmdlineConflict = map[string]*tunedv1.BootcmdlineConflict{}
+	controller.recommendSelected = map[string]TunedRecommendSource{}
+	controller.priorityConflicts = map[string][]TunedRecommendSource{}
+	controller.rollouts = map[TunedRecommendSource]rolloutState{}
+	controller.rolloutUpdated = map[string]*tunedv1.Profile{}
+	controller.rolloutWaves = map[TunedRecommendSource]int{}
+	controller.tunedRevisions = map[string]string{}
 
 	// Initial event to bootstrap CR if it doesn't exist.
 	controller.workqueue.AddRateLimited(wqKey{kind: wqKindTuned, name: tunedv1.TunedDefaultResourceName})
@@ -126,6 +174,7 @@ func NewController() (*Controller, error) {
 	if err != nil {
 		return nil, err
 	}
+	controller.recorder = ntoclient.NewEventRecorder(controller.clients.Kube, version.OperatorFilename, "")
 
 	// ClusterOperator
 	controller.clients.ConfigV1Client, err = configv1client.NewForConfig(controller.kubeconfig)
@@ -276,6 +325,7 @@ func (c *Controller) sync(key wqKey) error {
 			klog.V(2).Infof("sync(): Pod %s/%s label(s) change is Node %s wide", key.namespace, key.name, nodeName)
 			// Trigger a Profile update
 			c.workqueue.AddRateLimited(wqKey{kind: wqKindProfile, namespace: ntoconfig.WatchNamespace(), name: nodeName})
+			c.enqueueTunedPreviewUpdate()
 		}
 		return nil
 
@@ -287,6 +337,7 @@ func (c *Controller) sync(key wqKey) error {
 			if errors.IsNotFound(err) {
 				// Trigger Profile update/deletion for this node; syncProfile() will handle the deletion and also update internal data structures
 				c.workqueue.AddRateLimited(wqKey{kind: wqKindProfile, namespace: ntoconfig.WatchNamespace(), name: key.name})
+				c.enqueueTunedPreviewUpdate()
 				return nil
 			}
 			return fmt.Errorf("failed to process Node %s change: %v", key.name, err)
@@ -296,9 +347,41 @@ func (c *Controller) sync(key wqKey) error {
 			klog.V(2).Infof("sync(): Node %s label(s) changed", key.name)
 			// Trigger a Profile update
 			c.workqueue.AddRateLimited(wqKey{kind: wqKindProfile, namespace: ntoconfig.WatchNamespace(), name: key.name})
+			c.enqueueTunedPreviewUpdate()
 		}
 		return nil
 
+	case key.kind == wqKindTunedConfigMap:
+		klog.V(2).Infof("sync(): ConfigMap %s/%s", key.namespace, key.name)
+
+		tunedList, err := c.listers.TunedResources.List(labels.Everything())
+		if err != nil {
+			return fmt.Errorf("failed to list Tuned: %v", err)
+		}
+		if !tunedsReferenceConfigMap(tunedList, key.name) {
+			return nil
+		}
+		// The Valid and Conflicting conditions depend on the ConfigMap data.
+		c.enqueueTunedStatusUpdate()
+		if !tunedsReferenceConfigMap(tunedsActive(tunedList), key.name) {
+			return nil
+		}
+
+		// Node-specific variables in the ConfigMap data may need Node events.
+		err = c.enableInformers()
+		if err != nil {
+			return fmt.Errorf("failed to enable/disable informers: %v", err)
+		}
+
+		// TuneD profile data sourced from the ConfigMap may have changed.
+		// Trigger profile calculations/updates for all Tuned Profiles in the cluster.
+		err = c.enqueueProfileUpdates()
+		if err != nil {
+			return err
+		}
+
+		return nil
+
 	case key.kind == wqKindConfigMap && key.namespace == metrics.AuthConfigMapNamespace:
 		klog.V(2).Infof("sync(): wqKindConfigMap %s: %s/%s", key.kind, key.namespace, key.name)
 
@@ -367,6 +450,15 @@ func (c *Controller) sync(key wqKey) error {
 		}
 		return nil
 
+	case key.kind == wqKindTunedStatus:
+		klog.V(2).Infof("sync(): Tuned status")
+
+		err = c.syncTunedStatuses()
+		if err != nil {
+			return fmt.Errorf("failed to sync Tuned status: %v", err)
+		}
+		return nil
+
 	case key.kind == wqKindProfile:
 		klog.V(2).Infof("sync(): Profile %s", key.name)
 
@@ -414,6 +506,12 @@ func (c *Controller) sync(key wqKey) error {
 		return fmt.Errorf("failed to sync DaemonSet: %v", err)
 	}
 
+	// Tuned CR changed, record its revision before the rendered profile sets referencing it.
+	err = c.syncTunedRevisions()
+	if err != nil {
+		return fmt.Errorf("failed to sync Tuned revisions: %v", err)
+	}
+
 	// Tuned CR changed, this can affect all profiles, list them and trigger profile updates
 	klog.V(2).Infof("sync(): Tuned %s", key.name)
 
@@ -422,6 +520,9 @@ func (c *Controller) sync(key wqKey) error {
 		return err
 	}
 
+	// Tuned CR change can affect the validity of all Tuned CRs, recalculate their status.
+	c.enqueueTunedStatusUpdate()
+
 	// Tuned CR change can also mean some MachineConfigs the operator created are no longer needed;
 	// removal of these will also rollback host settings such as kernel boot parameters.
 	if ntoconfig.InHyperShift() {
@@ -467,6 +568,34 @@ func (c *Controller) enqueueProfileUpdates() error {
 	return nil
 }
 
+// enqueueTunedStatusUpdate enqueues status calculation/update for all Tuned CRs.
+// Multiple requests are coalesced by the workqueue until the status is synced.
+func (c *Controller) enqueueTunedStatusUpdate() {
+	c.workqueue.Add(wqKey{kind: wqKindTunedStatus, namespace: ntoconfig.WatchNamespace()})
+}
+
+// enqueueTunedPreviewUpdate enqueues status calculation/update for all Tuned CRs
+// if there is a Tuned CR in the dry-run mode.  The preview of such Tuned CRs
+// depends on Node and Pod labels, which need not change any Profile.
+func (c *Controller) enqueueTunedPreviewUpdate() {
+	if ntoconfig.InHyperShift() {
+		// No preview is calculated on HyperShift.
+		return
+	}
+
+	tunedList, err := c.listers.TunedResources.List(labels.Everything())
+	if err != nil {
+		klog.Errorf("failed to list Tuned: %v", err)
+		return
+	}
+	for _, tuned := range tunedList {
+		if util.HasDryRunAnnotation(tuned.Annotations) {
+			c.enqueueTunedStatusUpdate()
+			return
+		}
+	}
+}
+
 func (c *Controller) syncTunedDefault() (*tunedv1.Tuned, error) {
 	crMf := ntomf.TunedCustomResource()
 
@@ -580,6 +709,35 @@ func (c *Controller) syncDaemonSet(tuned *tunedv1.Tuned) error {
 	return nil
 }
 
+// computeProfile calculates the profile for Node 'nodeName' with the Node labels
+// and annotations referenced by the TuneD profiles expanded.  Returns the computed
+// profile and the referenced Node labels and annotations which are missing.
+func (c *Controller) computeProfile(nodeName string) (ComputedProfile, []string, error) {
+	var (
+		computed             ComputedProfile
+		missingNodeVariables []string
+		err                  error
+	)
+
+	if ntoconfig.InHyperShift() {
+		computed, err = c.pc.calculateProfileHyperShift(nodeName)
+	} else {
+		computed, err = c.pc.calculateProfile(nodeName)
+	}
+	if err != nil {
+		return computed, nil, err
+	}
+
+	computed.UnexpandedProfiles = computed.AllProfiles
+	if node, err := c.listers.Nodes.Get(nodeName); err == nil {
+		computed.AllProfiles, missingNodeVariables = expandNodeVariables(computed.AllProfiles, node.Labels, node.Annotations)
+	} else if !errors.IsNotFound(err) {
+		return computed, nil, err
+	}
+
+	return computed, missingNodeVariables, nil
+}
+
 func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 	profileMf := ntomf.TunedProfile()
 	profileMf.ObjectMeta.OwnerReferences = getDefaultTunedRefs(tuned)
@@ -590,6 +748,12 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 	if err != nil {
 		// Remove Profiles for Nodes which no longer exist.
 		if errors.IsNotFound(err) {
+			if _, ok := c.recommendSelected[nodeName]; ok {
+				delete(c.recommendSelected, nodeName)
+				delete(c.priorityConflicts, nodeName)
+				delete(c.rolloutUpdated, nodeName)
+				c.enqueueTunedStatusUpdate()
+			}
 			klog.V(2).Infof("syncProfile(): deleting Profile %s", nodeName)
 			err = c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).Delete(context.TODO(), nodeName, metav1.DeleteOptions{})
 			if err != nil && errors.IsNotFound(err) {
@@ -610,21 +774,37 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 		return nil
 	}
 
-	var computed ComputedProfile
-	if ntoconfig.InHyperShift() {
-		computed, err = c.pc.calculateProfileHyperShift(nodeName)
-		if err != nil {
-			return err
-		}
-	} else {
-		computed, err = c.pc.calculateProfile(nodeName)
-		if err != nil {
-			return err
-		}
+	computed, missingNodeVariables, err := c.computeProfile(nodeName)
+	if err != nil {
+		return err
 	}
 
 	metrics.ProfileCalculated(profileMf.Name, computed.TunedProfileName)
 
+	if len(missingNodeVariables) > 0 {
+		klog.Warningf("TuneD profiles for Node %s reference missing Node labels or annotations: %s", nodeName, strings.Join(missingNodeVariables, ", "))
+	}
+
+	if source, ok := c.recommendSelected[nodeName]; !ok || source != computed.Source {
+		c.recommendSelected[nodeName] = computed.Source
+		c.enqueueTunedStatusUpdate()
+	}
+	if !reflect.DeepEqual(c.priorityConflicts[nodeName], computed.PriorityConflicts) {
+		if computed.PriorityConflicts != nil {
+			klog.Warningf("recommend items %s have the same priority and select different TuneD profiles for Node %s",
+				recommendSourcesString(computed.PriorityConflicts), nodeName)
+			c.priorityConflicts[nodeName] = computed.PriorityConflicts
+		} else {
+			delete(c.priorityConflicts, nodeName)
+		}
+		c.enqueueTunedStatusUpdate()
+	}
+
+	revision, err := c.syncRenderedRevision(tuned, computed)
+	if err != nil {
+		return fmt.Errorf("failed to sync revision of Profile %s: %v", nodeName, err)
+	}
+
 	profile, err := c.listers.TunedProfiles.Get(profileMf.Name)
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -639,10 +819,13 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 
 			klog.V(2).Infof("syncProfile(): Profile %s not found, creating one [%s]", profileMf.Name, computed.TunedProfileName)
 			profileMf.Annotations = updateDeferredAnnotation(profileMf.Annotations, computed.Deferred)
+			profileMf.Annotations[tunedv1.TunedRevisionAnnotationKey] = revision
 			profileMf.Spec.Config.TunedProfile = computed.TunedProfileName
 			profileMf.Spec.Config.Debug = computed.Operand.Debug
 			profileMf.Spec.Config.Verbosity = computed.Operand.Verbosity
 			profileMf.Spec.Config.TuneDConfig = computed.Operand.TuneDConfig
+			profileMf.Spec.Config.RollbackOnDegraded = computed.Operand.RollbackOnDegraded
+			profileMf.Spec.Config.VerifyInterval = computed.Operand.VerifyInterval
 			profileMf.Spec.Profile = computed.AllProfiles
 			profileMf.Status.Conditions = tunedpkg.InitializeStatusConditions()
 			_, err = c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).Create(context.TODO(), profileMf, metav1.CreateOptions{})
@@ -701,17 +884,33 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 	}
 
 	anns := updateDeferredAnnotation(profile.Annotations, computed.Deferred)
+	anns[tunedv1.TunedRevisionAnnotationKey] = revision
+
+	upToDate := profileUpToDate(profile, computed)
+	// Deferred updates are not applied by the TuneD daemon until the Node reboots,
+	// there is nothing to wait for.
+	rollout := computed.Rollout != nil && !util.IsDeferredUpdate(computed.Deferred)
+	if rollout {
+		allowed, err := c.syncRollout(computed, revision)
+		if err != nil {
+			return fmt.Errorf("failed to sync rollout for Profile %s: %v", nodeName, err)
+		}
+		if !upToDate && !allowed {
+			// Reconsider the update once the current wave is applied.
+			klog.V(2).Infof("syncProfile(): holding back update of Profile %s by rollout strategy", nodeName)
+			c.workqueue.AddAfter(wqKey{kind: wqKindProfile, namespace: ntoconfig.WatchNamespace(), name: nodeName}, rolloutRequeueInterval)
+			// The TuneD profile in the spec is still selected by the previous recommend item.
+			return c.updateProfileStatus(profile, profileOperatorStatus(profile.Status.SelectedBy, computed, missingNodeVariables))
+		}
+	}
 
 	// Minimize updates
-	if profile.Spec.Config.TunedProfile == computed.TunedProfileName &&
-		profile.Spec.Config.Debug == computed.Operand.Debug &&
-		profile.Spec.Config.Verbosity == computed.Operand.Verbosity &&
-		reflect.DeepEqual(profile.Spec.Config.TuneDConfig, computed.Operand.TuneDConfig) &&
-		reflect.DeepEqual(profile.Spec.Profile, computed.AllProfiles) &&
+	if upToDate &&
 		util.GetDeferredUpdateAnnotation(profile.Annotations) == util.GetDeferredUpdateAnnotation(anns) &&
+		profile.Annotations[tunedv1.TunedRevisionAnnotationKey] == revision &&
 		profile.Spec.Config.ProviderName == providerName {
 		klog.V(2).Infof("syncProfile(): no need to update Profile %s", nodeName)
-		return nil
+		return c.updateProfileStatus(profile, profileOperatorStatus(profileSelection(computed), computed, missingNodeVariables))
 	}
 	profile = profile.DeepCopy() // never update the objects from cache
 	profile.Annotations = anns
@@ -719,17 +918,61 @@ func (c *Controller) syncProfile(tuned *tunedv1.Tuned, nodeName string) error {
 	profile.Spec.Config.Debug = computed.Operand.Debug
 	profile.Spec.Config.Verbosity = computed.Operand.Verbosity
 	profile.Spec.Config.TuneDConfig = computed.Operand.TuneDConfig
+	profile.Spec.Config.RollbackOnDegraded = computed.Operand.RollbackOnDegraded
+	profile.Spec.Config.VerifyInterval = computed.Operand.VerifyInterval
 	profile.Spec.Config.ProviderName = providerName
 	profile.Spec.Profile = computed.AllProfiles
 	profile.Status.Conditions = tunedpkg.InitializeStatusConditions()
 
 	klog.V(2).Infof("syncProfile(): updating Profile %s [%s]", profile.Name, computed.TunedProfileName)
-	_, err = c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).Update(context.TODO(), profile, metav1.UpdateOptions{})
+	profile, err = c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).Update(context.TODO(), profile, metav1.UpdateOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to update Profile %s: %v", profile.Name, err)
+		return fmt.Errorf("failed to update Profile %s: %v", nodeName, err)
+	}
+	if computed.Rollout != nil {
+		c.rolloutUpdated[nodeName] = profile
+	}
+	if rollout && !upToDate {
+		c.rolloutWaves[computed.Source]++
 	}
 	klog.Infof("updated profile %s [%s] (deferred=%v)", profile.Name, computed.TunedProfileName, util.GetDeferredUpdateAnnotation(profile.Annotations))
 
+	return c.updateProfileStatus(profile, profileOperatorStatus(profileSelection(computed), computed, missingNodeVariables))
+}
+
+// profileOperatorStatus returns a function setting the Profile status fields owned
+// by the operator: the recommend item 'selection' which selected the TuneD profile
+// in the Profile spec and the node-specific variables 'missing' referencing missing
+// Node labels or annotations.  The kernel arguments conflict is cleared once the
+// computed profile 'computed' no longer contributes to the kernel command-line of a pool.
+func profileOperatorStatus(selection *tunedv1.ProfileSelection, computed ComputedProfile, missing []string) func(*tunedv1.ProfileStatus) {
+	return func(status *tunedv1.ProfileStatus) {
+		status.SelectedBy = selection
+		status.MissingNodeVariables = missing
+		if !usesMachineConfig(computed) {
+			status.BootcmdlineConflict = nil
+		}
+	}
+}
+
+// updateProfileStatus updates the status of Profile 'profile' by 'update' in a single
+// status update.  Nothing is updated if 'update' leaves the status unchanged.
+func (c *Controller) updateProfileStatus(profile *tunedv1.Profile, update func(*tunedv1.ProfileStatus)) error {
+	status := profile.Status.DeepCopy()
+	update(status)
+	if reflect.DeepEqual(&profile.Status, status) {
+		return nil
+	}
+
+	profile = profile.DeepCopy() // never update the objects from cache
+	profile.Status = *status
+
+	klog.V(2).Infof("updateProfileStatus(): updating status of Profile %s", profile.Name)
+	_, err := c.clients.Tuned.TunedV1().Profiles(ntoconfig.WatchNamespace()).UpdateStatus(context.TODO(), profile, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update status of Profile %s: %v", profile.Name, err)
+	}
+
 	return nil
 }
 
@@ -783,7 +1026,7 @@ func (c *Controller) syncMachineConfig(labels map[string]string, profile *tunedv
 		return nil
 	}
 
-	if ok := c.allNodesAgreeOnBootcmdline(nodes); !ok {
+	if ok := c.allNodesAgreeOnBootcmdline(pools[0].ObjectMeta.Name, nodes); !ok {
 		// Log an error and do not requeue, this is a configuration issue.
 		klog.Errorf("not all %d Nodes in MCP %v agree on bootcmdline: %s", len(nodes), pools[0].ObjectMeta.Name, bootcmdline)
 		return nil
@@ -806,9 +1049,13 @@ func (c *Controller) syncMachineConfig(labels map[string]string, profile *tunedv
 			mc = NewMachineConfig(name, annotations, labels, kernelArguments)
 			_, err = c.clients.MC.MachineconfigurationV1().MachineConfigs().Create(context.TODO(), mc, metav1.CreateOptions{})
 			if err != nil {
+				metrics.MachineConfigSynced(name, metrics.MachineConfigFailed)
 				return fmt.Errorf("failed to create MachineConfig %s: %v", mc.ObjectMeta.Name, err)
 			}
+			metrics.MachineConfigSynced(name, metrics.MachineConfigCreated)
 			klog.Infof("created MachineConfig %s with%s", mc.ObjectMeta.Name, MachineConfigGenerationLogLine(len(bootcmdline) != 0, bootcmdline))
+			ntoclient.RecordProfileEvent(c.recorder, profile, corev1.EventTypeNormal, tunedv1.EventReasonMachineConfigUpdated,
+				fmt.Sprintf("Created MachineConfig %s with%s", mc.ObjectMeta.Name, MachineConfigGenerationLogLine(len(bootcmdline) != 0, bootcmdline)))
 			return nil
 		}
 		return err
@@ -831,21 +1078,32 @@ func (c *Controller) syncMachineConfig(labels map[string]string, profile *tunedv
 	klog.V(2).Infof("syncMachineConfig(): updating MachineConfig %s with%s", mc.ObjectMeta.Name, l)
 	_, err = c.clients.MC.MachineconfigurationV1().MachineConfigs().Update(context.TODO(), mc, metav1.UpdateOptions{})
 	if err != nil {
+		metrics.MachineConfigSynced(name, metrics.MachineConfigFailed)
 		return fmt.Errorf("failed to update MachineConfig %s: %v", mc.ObjectMeta.Name, err)
 	}
+	metrics.MachineConfigSynced(name, metrics.MachineConfigUpdated)
 
 	klog.Infof("updated MachineConfig %s with%s", mc.ObjectMeta.Name, l)
+	ntoclient.RecordProfileEvent(c.recorder, profile, corev1.EventTypeNormal, tunedv1.EventReasonMachineConfigUpdated,
+		fmt.Sprintf("Updated MachineConfig %s with%s", mc.ObjectMeta.Name, l))
 
 	return nil
 }
 
 // allNodesAgreeOnBootcmdline returns true if the current cached annotation 'TunedBootcmdlineAnnotationKey'
-// of all Nodes in slice 'nodes' has the same value.
-func (c *Controller) allNodesAgreeOnBootcmdline(nodes []*corev1.Node) bool {
+// of all Nodes in slice 'nodes' of pool 'pool' has the same value.  The disagreement is reported
+// in the status of the Profiles of the Nodes and in the status of the Tuned objects involved.
+func (c *Controller) allNodesAgreeOnBootcmdline(pool string, nodes []*corev1.Node) bool {
 	if len(nodes) == 0 {
 		return true
 	}
 
+	conflict := computeBootcmdlineConflict(pool, nodes, c.pc.state.bootcmdline, c.recommendSelected)
+	if err := c.syncBootcmdlineConflict(pool, nodes, conflict); err != nil {
+		// Not critical, the report is refreshed on the next sync of the pool.
+		klog.Errorf("failed to report bootcmdline conflict in pool %s: %v", pool, err)
+	}
+
 	match := true
 	bootcmdline := c.pc.state.bootcmdline[nodes[0].ObjectMeta.Name]
 	for _, node := range nodes[1:] {
@@ -873,7 +1131,7 @@ func (c *Controller) syncMachineConfigHyperShift(nodePoolName string, profile *t
 	}
 
 	bootcmdline := c.pc.state.bootcmdline[profile.Name]
-	if ok := c.allNodesAgreeOnBootcmdline(nodes); !ok {
+	if ok := c.allNodesAgreeOnBootcmdline(nodePoolName, nodes); !ok {
 		return fmt.Errorf("not all %d Nodes in NodePool %v agree on bootcmdline: %s", len(nodes), nodePoolName, bootcmdline)
 	}
 
@@ -901,9 +1159,13 @@ func (c *Controller) syncMachineConfigHyperShift(nodePoolName string, profile *t
 			}
 			_, err = c.clients.ManagementKube.CoreV1().ConfigMaps(ntoconfig.OperatorNamespace()).Create(context.TODO(), mcConfigMap, metav1.CreateOptions{})
 			if err != nil {
+				metrics.MachineConfigSynced(mcName, metrics.MachineConfigFailed)
 				return fmt.Errorf("failed to create ConfigMap %s for MachineConfig %s: %v", configMapName, mc.ObjectMeta.Name, err)
 			}
+			metrics.MachineConfigSynced(mcName, metrics.MachineConfigCreated)
 			klog.Infof("created ConfigMap %s for MachineConfig %s with%s", configMapName, mc.ObjectMeta.Name, MachineConfigGenerationLogLine(len(bootcmdline) != 0, bootcmdline))
+			ntoclient.RecordProfileEvent(c.recorder, profile, corev1.EventTypeNormal, tunedv1.EventReasonMachineConfigUpdated,
+				fmt.Sprintf("Created MachineConfig %s with%s", mc.ObjectMeta.Name, MachineConfigGenerationLogLine(len(bootcmdline) != 0, bootcmdline)))
 			return nil
 		}
 		return err
@@ -960,10 +1222,14 @@ func (c *Controller) syncMachineConfigHyperShift(nodePoolName string, profile *t
 
 	_, err = c.clients.ManagementKube.CoreV1().ConfigMaps(ntoconfig.OperatorNamespace()).Update(context.TODO(), mcConfigMap, metav1.UpdateOptions{})
 	if err != nil {
+		metrics.MachineConfigSynced(mcName, metrics.MachineConfigFailed)
 		return fmt.Errorf("failed to update ConfigMap for MachineConfig %s: %v", mcConfigMap.Name, err)
 	}
+	metrics.MachineConfigSynced(mcName, metrics.MachineConfigUpdated)
 
 	klog.Infof("updated ConfigMap %s for MachineConfig %s with%s", mcConfigMap.Name, mc.ObjectMeta.Name, l)
+	ntoclient.RecordProfileEvent(c.recorder, profile, corev1.EventTypeNormal, tunedv1.EventReasonMachineConfigUpdated,
+		fmt.Sprintf("Updated MachineConfig %s with%s", mc.ObjectMeta.Name, l))
 
 	return nil
 }
@@ -1069,7 +1335,7 @@ func (c *Controller) getMachineConfigNamesForTuned() (map[string]bool, error) {
 
 	mcNames := map[string]bool{}
 
-	for _, recommend := range TunedRecommend(tunedList) {
+	for _, recommend := range TunedRecommend(tunedsActive(tunedList)) {
 		if recommend.Profile == nil || recommend.MachineConfigLabels == nil {
 			continue
 		}
@@ -1096,7 +1362,7 @@ func (c *Controller) getConfigMapNamesForTuned() (map[string]bool, error) {
 	}
 
 	cmNames := map[string]bool{}
-	for _, tuned := range tunedList {
+	for _, tuned := range tunedsActive(tunedList) {
 		nodePoolName := tuned.Labels[hypershiftNodePoolNameLabel]
 		for _, recommend := range tuned.Spec.Recommend {
 			// nodePoolName may be an empty string in the case of the default profile
@@ -1147,6 +1413,12 @@ func (c *Controller) informerEventHandler(workqueueKey wqKey) cache.ResourceEven
 					return
 				}
 			}
+			if tunedOld, ok := o.(*tunedv1.Tuned); ok {
+				if tunedNew, ok := n.(*tunedv1.Tuned); ok && tunedStatusOnlyUpdate(tunedOld, tunedNew) {
+					// Don't add Tuned status updates, these are the result of syncTunedStatuses().
+					return
+				}
+			}
 			klog.V(2).Infof("add event to workqueue due to %s (update)", util.ObjectInfo(n))
 			c.workqueue.Add(wqKey{kind: workqueueKey.kind, namespace: newAccessor.GetNamespace(), name: newAccessor.GetName()})
 		},
@@ -1176,6 +1448,15 @@ func (c *Controller) informerEventHandler(workqueueKey wqKey) cache.ResourceEven
 	}
 }
 
+// tunedStatusOnlyUpdate returns true if the only difference between Tuned objects
+// 'o' and 'n' is in their status.  Periodic resyncs are not considered status-only.
+func tunedStatusOnlyUpdate(o, n *tunedv1.Tuned) bool {
+	return o.ResourceVersion != n.ResourceVersion &&
+		o.Generation == n.Generation &&
+		reflect.DeepEqual(o.Labels, n.Labels) &&
+		reflect.DeepEqual(o.Annotations, n.Annotations)
+}
+
 // enableNodeInformer enables/disables event handling for Nodes.
 func (c *Controller) enableNodeInformer(enable bool) error {
 	if (enable && c.node.informerEnabled) || (!enable && !c.node.informerEnabled) {
@@ -1337,6 +1618,9 @@ func (c *Controller) run(ctx context.Context) error {
 		return err
 	}
 
+	crInformer := kubeNTOInformerFactory.Apps().V1().ControllerRevisions()
+	c.listers.ControllerRevisions = crInformer.Lister().ControllerRevisions(ntoconfig.WatchNamespace())
+
 	trInformer := tunedInformerFactory.Tuned().V1().Tuneds()
 	c.listers.TunedResources = trInformer.Lister().Tuneds(ntoconfig.WatchNamespace())
 	if _, err := trInformer.Informer().AddEventHandler(c.informerEventHandler(wqKey{kind: wqKindTuned})); err != nil {
@@ -1349,9 +1633,17 @@ func (c *Controller) run(ctx context.Context) error {
 		return err
 	}
 
+	cmInformer := kubeNTOInformerFactory.Core().V1().ConfigMaps()
+	c.listers.TunedConfigMaps = cmInformer.Lister().ConfigMaps(ntoconfig.WatchNamespace())
+	if _, err := cmInformer.Informer().AddEventHandler(c.informerEventHandler(wqKey{kind: wqKindTunedConfigMap})); err != nil {
+		return err
+	}
+
 	InformerFuncs := []cache.InformerSynced{
 		coInformer.Informer().HasSynced,
 		dsInformer.Informer().HasSynced,
+		crInformer.Informer().HasSynced,
+		cmInformer.Informer().HasSynced,
 		trInformer.Informer().HasSynced,
 		tpInformer.Informer().HasSynced,
 	}
@@ -1414,7 +1706,7 @@ func (c *Controller) run(ctx context.Context) error {
 	}
 
 	configInformerFactory.Start(ctx.Done())  // ClusterOperator
-	kubeNTOInformerFactory.Start(ctx.Done()) // DaemonSet
+	kubeNTOInformerFactory.Start(ctx.Done()) // DaemonSet/ConfigMap
 	tunedInformerFactory.Start(ctx.Done())   // Tuned/Profile
 
 	if ntoconfig.InHyperShift() {
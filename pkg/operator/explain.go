@@ -0,0 +1,177 @@
+package operator
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kcorelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	mcfglisters "github.com/openshift/client-go/machineconfiguration/listers/machineconfiguration/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoclient "github.com/openshift/cluster-node-tuning-operator/pkg/client"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+	ntolisters "github.com/openshift/cluster-node-tuning-operator/pkg/generated/listers/tuned/v1"
+)
+
+// RecommendExplanation explains whether a recommend item of a Tuned object
+// selects a TuneD profile for a Node.
+type RecommendExplanation struct {
+	// Source identifies the recommend item.
+	Source TunedRecommendSource
+	// Profile is the TuneD profile recommended by the item.
+	Profile string
+	// Priority of the recommend item.
+	Priority *uint64
+	// Matched is true if the recommend item matches the Node.
+	Matched bool
+	// Selected is true for the matching recommend item with the highest priority,
+	// which selects the TuneD profile for the Node.
+	Selected bool
+	// Reason explains why the recommend item matched or not.
+	Reason string
+}
+
+// NewOfflineProfileCalculator returns a ProfileCalculator working with the Tuned
+// objects 'tuneds', Nodes 'nodes', Pods 'pods' and MachineConfigPools 'pools'
+// instead of the objects from the cluster.
+func NewOfflineProfileCalculator(tuneds []*tunedv1.Tuned, nodes []*corev1.Node, pods []*corev1.Pod, pools []*mcfgv1.MachineConfigPool) (*ProfileCalculator, error) {
+	var (
+		namespaced   = cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+		tunedIndexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, namespaced)
+		nodeIndexer  = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		podIndexer   = cache.NewIndexer(cache.MetaNamespaceKeyFunc, namespaced)
+		poolIndexer  = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	)
+
+	for _, tuned := range tuneds {
+		tuned = tuned.DeepCopy()
+		tuned.Namespace = ntoconfig.WatchNamespace()
+		if err := tunedIndexer.Add(tuned); err != nil {
+			return nil, fmt.Errorf("failed to add Tuned %s: %v", tuned.Name, err)
+		}
+	}
+	for _, node := range nodes {
+		if err := nodeIndexer.Add(node); err != nil {
+			return nil, fmt.Errorf("failed to add Node %s: %v", node.Name, err)
+		}
+	}
+	for _, pod := range pods {
+		if err := podIndexer.Add(pod); err != nil {
+			return nil, fmt.Errorf("failed to add Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+	for _, pool := range pools {
+		if err := poolIndexer.Add(pool); err != nil {
+			return nil, fmt.Errorf("failed to add MachineConfigPool %s: %v", pool.Name, err)
+		}
+	}
+
+	listers := &ntoclient.Listers{
+		TunedResources:     ntolisters.NewTunedLister(tunedIndexer).Tuneds(ntoconfig.WatchNamespace()),
+		Nodes:              kcorelisters.NewNodeLister(nodeIndexer),
+		Pods:               kcorelisters.NewPodLister(podIndexer),
+		MachineConfigPools: mcfglisters.NewMachineConfigPoolLister(poolIndexer),
+	}
+
+	pc := NewProfileCalculator(listers, nil)
+	for _, node := range nodes {
+		if _, err := pc.nodeChangeHandler(node.Name); err != nil {
+			return nil, fmt.Errorf("failed to process Node %s: %v", node.Name, err)
+		}
+	}
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" {
+			// Pod not scheduled, it cannot affect the profile selection.
+			continue
+		}
+		if _, _, err := pc.podChangeHandler(pod.Namespace, pod.Name); err != nil {
+			return nil, fmt.Errorf("failed to process Pod %s/%s: %v", pod.Namespace, pod.Name, err)
+		}
+	}
+
+	return pc, nil
+}
+
+// ExplainNode returns the explanations whether the recommend items of the active
+// Tuned objects select a TuneD profile for Node 'nodeName'.  The explanations are
+// in the order the recommend items are evaluated by calculateProfile.
+func (pc *ProfileCalculator) ExplainNode(nodeName string) ([]RecommendExplanation, error) {
+	var (
+		explanations []RecommendExplanation
+		pools        []*mcfgv1.MachineConfigPool
+		selected     bool
+	)
+
+	node, err := pc.listers.Nodes.Get(nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Node %s: %v", nodeName, err)
+	}
+	tunedList, err := pc.listers.TunedResources.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Tuned: %v", err)
+	}
+	if pc.listers.MachineConfigPools != nil {
+		pools, err = pc.getPoolsForNode(node)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get MachineConfigPools for Node %s: %v", nodeName, err)
+		}
+	}
+
+	for _, recommend := range TunedRecommend(tunedsActive(tunedList)) {
+		explanation := RecommendExplanation{
+			Source:   recommend.Source,
+			Priority: recommend.Priority,
+		}
+		if recommend.Profile != nil {
+			explanation.Profile = *recommend.Profile
+		}
+		explanation.Matched, explanation.Reason = pc.explainRecommend(recommend, nodeName, pools)
+		if explanation.Matched && !selected {
+			explanation.Selected = true
+			selected = true
+		}
+		explanations = append(explanations, explanation)
+	}
+
+	return explanations, nil
+}
+
+// explainRecommend returns whether the recommend item 'recommend' matches Node 'nodeName'
+// in MachineConfigPools 'pools' and the reason why.
+func (pc *ProfileCalculator) explainRecommend(recommend TunedRecommendInfo, nodeName string, pools []*mcfgv1.MachineConfigPool) (bool, string) {
+	result, path, _ := pc.recommendMatches(recommend, nodeName, func() ([]*mcfgv1.MachineConfigPool, error) {
+		return pools, nil
+	})
+
+	switch result {
+	case recommendNoMatchNodeSelector:
+		return false, "nodeSelector does not select the Node"
+	case recommendNoMatchRules:
+		return false, "no match rule matched"
+	case recommendMatchRules:
+		if len(path) == 0 {
+			return true, "catch-all item without match rules"
+		}
+		return true, "matched " + strings.Join(path, " AND ")
+	case recommendMatchMachineConfigLabels:
+		return true, fmt.Sprintf("machineConfigLabels selected by MachineConfigPool(s) %s", poolNames(pools))
+	}
+	if len(pools) == 0 {
+		return false, "machineConfigLabels set, but the Node is in no MachineConfigPool"
+	}
+	return false, fmt.Sprintf("machineConfigLabels not selected by MachineConfigPool(s) %s", poolNames(pools))
+}
+
+// poolNames returns the comma-separated names of MachineConfigPools 'pools'.
+func poolNames(pools []*mcfgv1.MachineConfigPool) string {
+	names := make([]string, 0, len(pools))
+	for _, pool := range pools {
+		names = append(names, pool.Name)
+	}
+	return strings.Join(names, ",")
+}
@@ -0,0 +1,152 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func TestExplainNode(t *testing.T) {
+	tuneds := []*tunedv1.Tuned{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{
+					{
+						Profile:  ptr.To("openshift-node"),
+						Priority: ptr.To[uint64](40),
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+			Spec: tunedv1.TunedSpec{
+				Recommend: []tunedv1.TunedRecommend{
+					{
+						Profile:  ptr.To("ingress"),
+						Priority: ptr.To[uint64](20),
+						Match: []tunedv1.TunedMatch{
+							{
+								Label: ptr.To("tuned.openshift.io/elasticsearch"),
+								Type:  ptr.To("pod"),
+							},
+						},
+					},
+					{
+						Profile:  ptr.To("worker"),
+						Priority: ptr.To[uint64](30),
+						Match: []tunedv1.TunedMatch{
+							{
+								Label: ptr.To("node-role.kubernetes.io/master"),
+							},
+							{
+								Label: ptr.To("node-role.kubernetes.io/worker"),
+								Match: []tunedv1.TunedMatch{
+									{
+										Label: ptr.To("example.com/tier"),
+										Value: ptr.To("gold"),
+									},
+								},
+							},
+						},
+					},
+					{
+						Profile:             ptr.To("rt"),
+						Priority:            ptr.To[uint64](10),
+						MachineConfigLabels: map[string]string{"machineconfiguration.openshift.io/role": "worker-rt"},
+					},
+					{
+						Profile:      ptr.To("infra"),
+						Priority:     ptr.To[uint64](5),
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/infra": ""}},
+					},
+				},
+			},
+		},
+	}
+	nodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "node1",
+				Labels: map[string]string{"node-role.kubernetes.io/worker": "", "example.com/tier": "gold"},
+			},
+		},
+	}
+	pods := []*corev1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default", Labels: map[string]string{"app": "web"}},
+			Spec:       corev1.PodSpec{NodeName: "node1"},
+		},
+	}
+	pools := []*mcfgv1.MachineConfigPool{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker"},
+			Spec: mcfgv1.MachineConfigPoolSpec{
+				NodeSelector:          &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/worker": ""}},
+				MachineConfigSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"machineconfiguration.openshift.io/role": "worker"}},
+			},
+		},
+	}
+
+	pc, err := NewOfflineProfileCalculator(tuneds, nodes, pods, pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, err := pc.ExplainNode("node1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []RecommendExplanation{
+		{
+			Source:   TunedRecommendSource{TunedName: "custom", Index: 3},
+			Profile:  "infra",
+			Priority: ptr.To[uint64](5),
+			Reason:   "nodeSelector does not select the Node",
+		},
+		{
+			Source:   TunedRecommendSource{TunedName: "custom", Index: 2},
+			Profile:  "rt",
+			Priority: ptr.To[uint64](10),
+			Reason:   "machineConfigLabels not selected by MachineConfigPool(s) worker",
+		},
+		{
+			Source:   TunedRecommendSource{TunedName: "custom", Index: 0},
+			Profile:  "ingress",
+			Priority: ptr.To[uint64](20),
+			Reason:   "no match rule matched",
+		},
+		{
+			Source:   TunedRecommendSource{TunedName: "custom", Index: 1},
+			Profile:  "worker",
+			Priority: ptr.To[uint64](30),
+			Matched:  true,
+			Selected: true,
+			Reason:   "matched node label node-role.kubernetes.io/worker AND node label example.com/tier=gold",
+		},
+		{
+			Source:   TunedRecommendSource{TunedName: "default", Index: 0},
+			Profile:  "openshift-node",
+			Priority: ptr.To[uint64](40),
+			Matched:  true,
+			Reason:   "catch-all item without match rules",
+		},
+	}
+
+	if len(have) != len(want) {
+		t.Fatalf("want %d explanations, have %d: %+v", len(want), len(have), have)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(have[i], want[i]) {
+			t.Errorf("failed test case %d:\n\twant: %+v\n\thave: %+v", i, want[i], have[i])
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package operator
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+const (
+	nodeVariableLabel      = "label"
+	nodeVariableAnnotation = "annotation"
+)
+
+// nodeVariableRegex matches the node-specific variables ${node.label:<key>} and
+// ${node.annotation:<key>} in TuneD profile data.
+var nodeVariableRegex = regexp.MustCompile(`\$\{node\.(` + nodeVariableLabel + `|` + nodeVariableAnnotation + `):([^}]+)\}`)
+
+// nodeVariableInvalidChars are the characters not allowed in the values of node-specific
+// variables, as they would inject new lines or sections into TuneD profiles.
+const nodeVariableInvalidChars = "\n\r["
+
+// expandNodeVariables returns copies of TuneD profiles 'profiles' with the node-specific
+// variables expanded to the values of Node labels 'nodeLabels' and annotations
+// 'nodeAnnotations'.  Variables referencing labels or annotations missing on the Node
+// or with values containing nodeVariableInvalidChars are kept unexpanded and returned
+// as a sorted list without duplicates.
+func expandNodeVariables(profiles []tunedv1.TunedProfile, nodeLabels, nodeAnnotations map[string]string) ([]tunedv1.TunedProfile, []string) {
+	var (
+		expanded = make([]tunedv1.TunedProfile, 0, len(profiles))
+		missing  = map[string]bool{}
+	)
+
+	for _, profile := range profiles {
+		if profile.Data == nil || !nodeVariableRegex.MatchString(*profile.Data) {
+			expanded = append(expanded, profile)
+			continue
+		}
+
+		data := nodeVariableRegex.ReplaceAllStringFunc(*profile.Data, func(variable string) string {
+			match := nodeVariableRegex.FindStringSubmatch(variable)
+			values := nodeLabels
+			if match[1] == nodeVariableAnnotation {
+				values = nodeAnnotations
+			}
+			value, ok := values[strings.TrimSpace(match[2])]
+			if !ok || strings.ContainsAny(value, nodeVariableInvalidChars) {
+				missing[variable] = true
+				return variable
+			}
+			return value
+		})
+		expanded = append(expanded, tunedv1.TunedProfile{Name: profile.Name, Data: &data})
+	}
+
+	return expanded, sortedStringSet(missing)
+}
+
+// nodeVariableKeys returns the keys of the Node labels or annotations (depending on
+// the variable 'kind') referenced by the node-specific variables of TuneD profiles 'profiles'.
+func nodeVariableKeys(profiles []tunedv1.TunedProfile, kind string) map[string]bool {
+	keys := map[string]bool{}
+
+	for _, profile := range profiles {
+		if profile.Data == nil {
+			continue
+		}
+		for _, match := range nodeVariableRegex.FindAllStringSubmatch(*profile.Data, -1) {
+			if match[1] == kind {
+				keys[strings.TrimSpace(match[2])] = true
+			}
+		}
+	}
+
+	return keys
+}
+
+// nodeVariablesUsed returns true if any of the TuneD profiles 'profiles' references
+// Node labels or annotations in node-specific variables.
+func nodeVariablesUsed(profiles []tunedv1.TunedProfile) bool {
+	for _, profile := range profiles {
+		if profile.Data != nil && nodeVariableRegex.MatchString(*profile.Data) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nodeAnnotationsReferenced returns the annotations of Node 'node' referenced by
+// the node-specific variables of the TuneD profiles of all Tuned objects, including
+// the TuneD profiles sourced from ConfigMaps.
+func (pc *ProfileCalculator) nodeAnnotationsReferenced(node *corev1.Node) (map[string]string, error) {
+	tunedList, err := pc.listers.TunedResources.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Tuned: %v", err)
+	}
+
+	annotations := map[string]string{}
+	for key := range nodeVariableKeys(tunedProfiles(tunedList, pc.listers.TunedConfigMaps), nodeVariableAnnotation) {
+		if value, ok := node.ObjectMeta.Annotations[key]; ok {
+			annotations[key] = value
+		}
+	}
+
+	return annotations, nil
+}
+
+// sortedStringSet returns the sorted members of set 'set'.
+func sortedStringSet(set map[string]bool) []string {
+	if len(set) == 0 {
+		return nil
+	}
+
+	ret := make([]string, 0, len(set))
+	for s := range set {
+		ret = append(ret, s)
+	}
+	sort.Strings(ret)
+
+	return ret
+}
@@ -0,0 +1,230 @@
+package operator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kcorelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoclient "github.com/openshift/cluster-node-tuning-operator/pkg/client"
+	ntolisters "github.com/openshift/cluster-node-tuning-operator/pkg/generated/listers/tuned/v1"
+)
+
+func TestExpandNodeVariables(t *testing.T) {
+	nodeLabels := map[string]string{
+		"example.com/somaxconn": "4096",
+	}
+	nodeAnnotations := map[string]string{
+		"example.com/isolated": "2-3",
+		"example.com/injected": "1\n[sysctl]\nkernel.panic=1",
+	}
+
+	tests := []struct {
+		profiles        []tunedv1.TunedProfile
+		expectedData    []string
+		expectedMissing []string
+	}{
+		{
+			profiles:        nil,
+			expectedData:    []string{},
+			expectedMissing: nil,
+		},
+		{
+			profiles: []tunedv1.TunedProfile{
+				{Name: ptr.To("a"), Data: ptr.To("[sysctl]\nnet.core.somaxconn=1024\n")},
+			},
+			expectedData:    []string{"[sysctl]\nnet.core.somaxconn=1024\n"},
+			expectedMissing: nil,
+		},
+		{
+			profiles: []tunedv1.TunedProfile{
+				{Name: ptr.To("a"), Data: ptr.To("[sysctl]\nnet.core.somaxconn=${node.label:example.com/somaxconn}\n")},
+				{Name: ptr.To("b"), Data: ptr.To("[variables]\nisolated_cores=${node.annotation: example.com/isolated }\n")},
+			},
+			expectedData: []string{
+				"[sysctl]\nnet.core.somaxconn=4096\n",
+				"[variables]\nisolated_cores=2-3\n",
+			},
+			expectedMissing: nil,
+		},
+		{
+			profiles: []tunedv1.TunedProfile{
+				{Name: ptr.To("a"), Data: ptr.To("[sysctl]\nnet.core.somaxconn=${node.label:example.com/missing}\n" +
+					"vm.nr_hugepages=${node.annotation:example.com/somaxconn}\nkernel.x=${node.label:example.com/missing}\n")},
+				{Name: ptr.To("b"), Data: ptr.To("[variables]\nisolated_cores=${node.annotation:example.com/isolated}\n")},
+			},
+			expectedData: []string{
+				"[sysctl]\nnet.core.somaxconn=${node.label:example.com/missing}\n" +
+					"vm.nr_hugepages=${node.annotation:example.com/somaxconn}\nkernel.x=${node.label:example.com/missing}\n",
+				"[variables]\nisolated_cores=2-3\n",
+			},
+			expectedMissing: []string{
+				"${node.annotation:example.com/somaxconn}",
+				"${node.label:example.com/missing}",
+			},
+		},
+		{
+			// Values which would inject new lines or sections are rejected.
+			profiles: []tunedv1.TunedProfile{
+				{Name: ptr.To("a"), Data: ptr.To("[sysctl]\nvm.nr_hugepages=${node.annotation:example.com/injected}\n")},
+			},
+			expectedData: []string{
+				"[sysctl]\nvm.nr_hugepages=${node.annotation:example.com/injected}\n",
+			},
+			expectedMissing: []string{
+				"${node.annotation:example.com/injected}",
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		expanded, missing := expandNodeVariables(tc.profiles, nodeLabels, nodeAnnotations)
+		data := []string{}
+		for _, profile := range expanded {
+			data = append(data, *profile.Data)
+		}
+		if !reflect.DeepEqual(data, tc.expectedData) {
+			t.Errorf("failed test case %d:\n\twant: %q\n\thave: %q", i, tc.expectedData, data)
+		}
+		if !reflect.DeepEqual(missing, tc.expectedMissing) {
+			t.Errorf("failed test case %d:\n\twant: %v\n\thave: %v", i, tc.expectedMissing, missing)
+		}
+	}
+}
+
+func TestNodeVariableKeys(t *testing.T) {
+	profiles := []tunedv1.TunedProfile{
+		{Name: ptr.To("a"), Data: ptr.To("[sysctl]\nx=${node.label:l1}\ny=${node.annotation:a1}\n")},
+		{Name: ptr.To("b"), Data: nil},
+		{Name: ptr.To("c"), Data: ptr.To("[sysctl]\nz=${node.annotation:a2} ${node.annotation:a1}\n")},
+	}
+
+	tests := []struct {
+		kind     string
+		expected map[string]bool
+	}{
+		{
+			kind:     nodeVariableLabel,
+			expected: map[string]bool{"l1": true},
+		},
+		{
+			kind:     nodeVariableAnnotation,
+			expected: map[string]bool{"a1": true, "a2": true},
+		},
+	}
+
+	for i, tc := range tests {
+		have := nodeVariableKeys(profiles, tc.kind)
+		if !reflect.DeepEqual(have, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %v\n\thave: %v", i, tc.expected, have)
+		}
+	}
+}
+
+func TestNodeLabelChangeRerender(t *testing.T) {
+	// No recommend item matches on Node labels, only the TuneD profile references one.
+	tuned := newTestTuned("custom",
+		[]tunedv1.TunedProfile{
+			{Name: ptr.To("openshift-hugepages"), Data: ptr.To("[main]\ninclude=openshift-node\n[sysctl]\nvm.nr_hugepages=${node.label:example.com/hugepages}\n")},
+		},
+		[]tunedv1.TunedRecommend{
+			{Profile: ptr.To("openshift-hugepages"), Priority: ptr.To[uint64](20)},
+		},
+	)
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "node1",
+			Labels: map[string]string{"kubernetes.io/os": "linux", "example.com/hugepages": "16"},
+		},
+	}
+
+	tunedIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := tunedIndexer.Add(tuned); err != nil {
+		t.Fatal(err)
+	}
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := nodeIndexer.Add(node); err != nil {
+		t.Fatal(err)
+	}
+	listers := &ntoclient.Listers{
+		TunedResources: ntolisters.NewTunedLister(tunedIndexer).Tuneds(tuned.Namespace),
+		Nodes:          kcorelisters.NewNodeLister(nodeIndexer),
+	}
+	c := &Controller{listers: listers, pc: NewProfileCalculator(listers, nil)}
+
+	if !c.pc.tunedsUseNodeLabels([]*tunedv1.Tuned{tuned}) {
+		t.Fatalf("want Node labels used by node-specific variables")
+	}
+
+	for i, value := range []string{"16", "32"} {
+		node = node.DeepCopy()
+		node.Labels["example.com/hugepages"] = value
+		if err := nodeIndexer.Update(node); err != nil {
+			t.Fatal(err)
+		}
+
+		change, err := c.pc.nodeChangeHandler(node.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !change {
+			t.Errorf("failed test case %d: want Node label change", i+1)
+		}
+		computed, _, err := c.computeProfile(node.Name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := "vm.nr_hugepages=" + value + "\n"
+		if len(computed.AllProfiles) != 1 || !strings.Contains(*computed.AllProfiles[0].Data, want) {
+			t.Errorf("failed test case %d:\n\twant: %q\n\thave: %v", i+1, want, tunedProfilesToString(computed.AllProfiles))
+		}
+	}
+}
+
+func TestNodeAnnotationsReferencedDataFrom(t *testing.T) {
+	tuned := newTestTuned("custom",
+		[]tunedv1.TunedProfile{
+			{Name: ptr.To("inline"), Data: ptr.To("[sysctl]\nx=${node.annotation:a1}\n")},
+			{Name: ptr.To("configmap"), DataFrom: &tunedv1.TunedProfileDataSource{ConfigMap: "custom", Key: "tuned.conf"}},
+		},
+		nil,
+	)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: tuned.Namespace},
+		Data:       map[string]string{"tuned.conf": "[sysctl]\ny=${node.annotation:a2}\n"},
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "node1",
+			Annotations: map[string]string{"a1": "1", "a2": "2", "a3": "3"},
+		},
+	}
+
+	tunedIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := tunedIndexer.Add(tuned); err != nil {
+		t.Fatal(err)
+	}
+	cmIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := cmIndexer.Add(cm); err != nil {
+		t.Fatal(err)
+	}
+	pc := NewProfileCalculator(&ntoclient.Listers{
+		TunedResources:  ntolisters.NewTunedLister(tunedIndexer).Tuneds(tuned.Namespace),
+		TunedConfigMaps: kcorelisters.NewConfigMapLister(cmIndexer).ConfigMaps(tuned.Namespace),
+	}, nil)
+
+	have, err := pc.nodeAnnotationsReferenced(node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{"a1": "1", "a2": "2"}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("failed test case:\n\twant: %v\n\thave: %v", want, have)
+	}
+}
@@ -0,0 +1,111 @@
+package operator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+// maxPriorityConflictNodes is the maximum number of Node names listed by the messages
+// reporting recommend items with the same priority selecting different TuneD profiles.
+const maxPriorityConflictNodes = 10
+
+// priorityConflictSources returns the recommend items 'conflicts' matching a Node
+// with the same priority as the selected recommend item 'selected' together with
+// 'selected', sorted.  Returns nil if there are no conflicts.
+func priorityConflictSources(selected TunedRecommendSource, conflicts []TunedRecommendSource) []TunedRecommendSource {
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	sources := append([]TunedRecommendSource{selected}, conflicts...)
+	sort.Slice(sources, func(i, j int) bool {
+		if sources[i].TunedName != sources[j].TunedName {
+			return sources[i].TunedName < sources[j].TunedName
+		}
+		return sources[i].Index < sources[j].Index
+	})
+
+	return sources
+}
+
+// recommendSourcesString returns a human-readable list of recommend items 'sources'.
+func recommendSourcesString(sources []TunedRecommendSource) string {
+	s := make([]string, 0, len(sources))
+	for _, source := range sources {
+		s = append(s, source.String())
+	}
+	return strings.Join(s, ", ")
+}
+
+// priorityConflictNodes returns the sorted names of the Nodes with conflicting
+// recommend items 'conflicts' (indexed by Node name).
+func priorityConflictNodes(conflicts map[string][]TunedRecommendSource) []string {
+	nodes := make([]string, 0, len(conflicts))
+	for nodeName := range conflicts {
+		nodes = append(nodes, nodeName)
+	}
+	sort.Strings(nodes)
+
+	return nodes
+}
+
+// priorityConflictNodesString returns a human-readable list of Node names 'nodes'
+// listing at most maxPriorityConflictNodes of them.
+func priorityConflictNodesString(nodes []string) string {
+	if len(nodes) <= maxPriorityConflictNodes {
+		return strings.Join(nodes, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(nodes[:maxPriorityConflictNodes], ", "), len(nodes)-maxPriorityConflictNodes)
+}
+
+// tunedPriorityConflicts returns human-readable reports of the conflicting recommend
+// items 'conflicts' (indexed by Node name) involving Tuned 'tuned'.  Each report lists
+// the recommend items in conflict and the names of the affected Nodes.
+func tunedPriorityConflicts(tuned *tunedv1.Tuned, conflicts map[string][]TunedRecommendSource) []string {
+	var reports []string
+
+	nodesBySources := map[string][]string{}
+	for _, nodeName := range priorityConflictNodes(conflicts) {
+		sources := conflicts[nodeName]
+		involved := false
+		for _, source := range sources {
+			involved = involved || source.TunedName == tuned.Name
+		}
+		if !involved {
+			continue
+		}
+		key := recommendSourcesString(sources)
+		nodesBySources[key] = append(nodesBySources[key], nodeName)
+	}
+
+	for sources, nodes := range nodesBySources {
+		reports = append(reports, fmt.Sprintf("%s on %d Node(s) %s", sources, len(nodes), priorityConflictNodesString(nodes)))
+	}
+	sort.Strings(reports)
+
+	return reports
+}
+
+// setTunedPriorityConflictStatus sets the PriorityConflict condition of Tuned status
+// 'status' for Tuned 'tuned' based on the conflicting recommend items 'conflicts'
+// (indexed by Node name).
+func setTunedPriorityConflictStatus(tuned *tunedv1.Tuned, status *tunedv1.TunedStatus, conflicts map[string][]TunedRecommendSource) {
+	conflictCondition := tunedv1.TunedStatusCondition{
+		Type: tunedv1.TunedPriorityConflict,
+	}
+	if reports := tunedPriorityConflicts(tuned, conflicts); len(reports) > 0 {
+		conflictCondition.Status = corev1.ConditionTrue
+		conflictCondition.Reason = "SamePriority"
+		conflictCondition.Message = "Recommend items with the same priority select different TuneD profiles: " + strings.Join(reports, "; ")
+	} else {
+		conflictCondition.Status = corev1.ConditionFalse
+		conflictCondition.Reason = "AsExpected"
+		conflictCondition.Message = "No recommend item conflicts with a recommend item of the same priority."
+	}
+	status.Conditions = setTunedStatusCondition(status.Conditions, &conflictCondition)
+}
@@ -0,0 +1,147 @@
+package operator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoclient "github.com/openshift/cluster-node-tuning-operator/pkg/client"
+)
+
+func TestCalculateProfilePriorityConflicts(t *testing.T) {
+	pc := NewProfileCalculator(&ntoclient.Listers{}, nil)
+	pc.state.nodeLabels["node1"] = map[string]string{"node-role.kubernetes.io/worker": ""}
+
+	newTuned := func(name string, recommend ...tunedv1.TunedRecommend) *tunedv1.Tuned {
+		return &tunedv1.Tuned{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       tunedv1.TunedSpec{Recommend: recommend},
+		}
+	}
+	newRecommend := func(profile string, priority uint64) tunedv1.TunedRecommend {
+		return tunedv1.TunedRecommend{
+			Profile:  ptr.To(profile),
+			Priority: ptr.To(priority),
+			Match: []tunedv1.TunedMatch{
+				{
+					Label: ptr.To("node-role.kubernetes.io/worker"),
+				},
+			},
+		}
+	}
+	catchAll := tunedv1.TunedRecommend{
+		Profile:  ptr.To("openshift-node"),
+		Priority: ptr.To[uint64](40),
+	}
+
+	tests := []struct {
+		tunedList []*tunedv1.Tuned
+		expected  []TunedRecommendSource
+	}{
+		{
+			tunedList: []*tunedv1.Tuned{
+				newTuned("default", catchAll),
+				newTuned("custom", newRecommend("custom", 20)),
+			},
+			expected: nil,
+		},
+		{
+			tunedList: []*tunedv1.Tuned{
+				newTuned("default", catchAll),
+				newTuned("custom", newRecommend("custom", 20)),
+				newTuned("other", newRecommend("other", 30), newRecommend("other-custom", 20)),
+			},
+			expected: []TunedRecommendSource{
+				{TunedName: "custom", Index: 0},
+				{TunedName: "other", Index: 1},
+			},
+		},
+		{
+			// The same TuneD profile recommended with the same priority is no conflict.
+			tunedList: []*tunedv1.Tuned{
+				newTuned("default", catchAll),
+				newTuned("custom", newRecommend("custom", 20)),
+				newTuned("other", newRecommend("custom", 20)),
+			},
+			expected: nil,
+		},
+	}
+
+	for i, tc := range tests {
+		computed, err := pc.calculateProfileFromTuneds("node1", tc.tunedList)
+		if err != nil {
+			t.Errorf("failed test case %d: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(computed.PriorityConflicts, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %+v\n\thave: %+v", i, tc.expected, computed.PriorityConflicts)
+		}
+	}
+}
+
+func TestSetTunedPriorityConflictStatus(t *testing.T) {
+	conflicts := map[string][]TunedRecommendSource{
+		"node1": {{TunedName: "a", Index: 0}, {TunedName: "b", Index: 1}},
+		"node2": {{TunedName: "a", Index: 0}, {TunedName: "b", Index: 1}},
+		"node3": {{TunedName: "b", Index: 0}, {TunedName: "c", Index: 0}},
+	}
+
+	tests := []struct {
+		tunedName       string
+		expectedStatus  corev1.ConditionStatus
+		expectedMessage string
+	}{
+		{
+			tunedName:       "a",
+			expectedStatus:  corev1.ConditionTrue,
+			expectedMessage: "Recommend items with the same priority select different TuneD profiles: a/recommend[0], b/recommend[1] on 2 Node(s) node1, node2",
+		},
+		{
+			tunedName:       "b",
+			expectedStatus:  corev1.ConditionTrue,
+			expectedMessage: "Recommend items with the same priority select different TuneD profiles: a/recommend[0], b/recommend[1] on 2 Node(s) node1, node2; b/recommend[0], c/recommend[0] on 1 Node(s) node3",
+		},
+		{
+			tunedName:       "d",
+			expectedStatus:  corev1.ConditionFalse,
+			expectedMessage: "No recommend item conflicts with a recommend item of the same priority.",
+		},
+	}
+
+	for i, tc := range tests {
+		tuned := &tunedv1.Tuned{ObjectMeta: metav1.ObjectMeta{Name: tc.tunedName}}
+		status := tunedv1.TunedStatus{}
+		setTunedPriorityConflictStatus(tuned, &status, conflicts)
+
+		if len(status.Conditions) != 1 || status.Conditions[0].Type != tunedv1.TunedPriorityConflict {
+			t.Errorf("failed test case %d: want a single PriorityConflict condition, have: %+v", i, status.Conditions)
+			continue
+		}
+		condition := status.Conditions[0]
+		if condition.Status != tc.expectedStatus || condition.Message != tc.expectedMessage {
+			t.Errorf("failed test case %d:\n\twant: %s %q\n\thave: %s %q", i, tc.expectedStatus, tc.expectedMessage, condition.Status, condition.Message)
+		}
+	}
+}
+
+func TestPriorityConflictNodesString(t *testing.T) {
+	var nodes []string
+	for i := 0; i < maxPriorityConflictNodes+5; i++ {
+		nodes = append(nodes, fmt.Sprintf("node%02d", i))
+	}
+
+	have := priorityConflictNodesString(nodes)
+	want := strings.Join(nodes[:maxPriorityConflictNodes], ", ") + " and 5 more"
+	if have != want {
+		t.Errorf("want: %q\nhave: %q", want, have)
+	}
+	if have := priorityConflictNodesString(nodes[:2]); have != "node00, node01" {
+		t.Errorf("want: %q\nhave: %q", "node00, node01", have)
+	}
+}
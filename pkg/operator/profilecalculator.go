@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	kcorelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/klog/v2"
 
 	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
@@ -37,6 +38,9 @@ type tunedState struct {
 	bootcmdline map[string]string
 	// Node name:   ^^^^^^
 	// bootcmdline         ^^^^^^
+	nodeAnnotations map[string]map[string]string
+	// Node name:       ^^^^^^
+	// Node annotation referenced by TuneD profiles: ^^^^^^
 }
 
 type ProfileCalculator struct {
@@ -54,6 +58,7 @@ func NewProfileCalculator(listers *ntoclient.Listers, clients *ntoclient.Clients
 	pc.state.podLabels = map[string]map[string]map[string]string{}
 	pc.state.providerIDs = map[string]string{}
 	pc.state.bootcmdline = map[string]string{}
+	pc.state.nodeAnnotations = map[string]map[string]string{}
 	return pc
 }
 
@@ -139,6 +144,16 @@ func (pc *ProfileCalculator) nodeChangeHandler(nodeName string) (bool, error) {
 		}
 	}
 
+	nodeAnnotationsNew, err := pc.nodeAnnotationsReferenced(node)
+	if err != nil {
+		return false, err
+	}
+	if !util.MapOfStringsEqual(nodeAnnotationsNew, pc.state.nodeAnnotations[nodeName]) {
+		// Node annotations referenced by node-specific variables of TuneD profiles changed
+		pc.state.nodeAnnotations[nodeName] = nodeAnnotationsNew
+		change = true
+	}
+
 	nodeLabelsNew := util.MapOfStringsCopy(node.Labels)
 
 	if !util.MapOfStringsEqual(nodeLabelsNew, pc.state.nodeLabels[nodeName]) {
@@ -153,10 +168,21 @@ func (pc *ProfileCalculator) nodeChangeHandler(nodeName string) (bool, error) {
 type ComputedProfile struct {
 	TunedProfileName string
 	AllProfiles      []tunedv1.TunedProfile
-	Deferred         util.DeferMode
-	MCLabels         map[string]string
-	NodePoolName     string
-	Operand          tunedv1.OperandConfig
+	// UnexpandedProfiles are AllProfiles before the Node labels and annotations
+	// they reference were expanded.
+	UnexpandedProfiles []tunedv1.TunedProfile
+	Deferred           util.DeferMode
+	MCLabels           map[string]string
+	NodePoolName       string
+	Operand            tunedv1.OperandConfig
+	Source             TunedRecommendSource
+	Priority           *uint64
+	MatchPath          []string
+	// PriorityConflicts are the recommend items matching the Node with the same
+	// priority as Source, but a different TuneD profile, including Source; nil
+	// if there are none.
+	PriorityConflicts []TunedRecommendSource
+	Rollout           *tunedv1.RolloutStrategy
 }
 
 type RecommendedProfile struct {
@@ -164,6 +190,10 @@ type RecommendedProfile struct {
 	Deferred         util.DeferMode
 	Labels           map[string]string
 	Config           tunedv1.OperandConfig
+	Source           TunedRecommendSource
+	Priority         *uint64
+	MatchPath        []string
+	Rollout          *tunedv1.RolloutStrategy
 }
 
 // calculateProfile calculates a tuned profile for Node nodeName.
@@ -182,57 +212,126 @@ func (pc *ProfileCalculator) calculateProfile(nodeName string) (ComputedProfile,
 		return ComputedProfile{}, fmt.Errorf("failed to list Tuned: %v", err)
 	}
 
-	profilesAll := tunedProfiles(tunedList)
+	return pc.calculateProfileFromTuneds(nodeName, tunedsActive(tunedList))
+}
+
+// recommendMatchResult is the result of matching a recommend item against a Node.
+type recommendMatchResult int
+
+const (
+	// recommendNoMatchNodeSelector means the recommend item's nodeSelector does not select the Node.
+	recommendNoMatchNodeSelector recommendMatchResult = iota
+	// recommendNoMatchRules means no node/pod label match rule matched.
+	recommendNoMatchRules
+	// recommendNoMatchMachineConfigLabels means no MachineConfigPool of the Node is
+	// selected by the recommend item's machineConfigLabels.
+	recommendNoMatchMachineConfigLabels
+	// recommendMatchRules means the node/pod label match rules matched.
+	recommendMatchRules
+	// recommendMatchMachineConfigLabels means a MachineConfigPool of the Node is selected
+	// by the recommend item's machineConfigLabels.
+	recommendMatchMachineConfigLabels
+)
+
+// recommendMatches returns whether recommend item 'recommend' selects its TuneD profile
+// for Node 'nodeName' and the path of the match rules (or machineConfigLabels) which
+// matched.  'nodePools' returns the MachineConfigPools of the Node, it is only called
+// for recommend items with machineConfigLabels.
+func (pc *ProfileCalculator) recommendMatches(recommend TunedRecommendInfo, nodeName string, nodePools func() ([]*mcfgv1.MachineConfigPool, error)) (recommendMatchResult, []string, error) {
+	if !pc.nodeSelectorMatches(recommend.NodeSelector, nodeName) {
+		// The Node is not selected by the recommend item's nodeSelector, neither
+		// node/pod label nor MachineConfig matching can select the profile.
+		return recommendNoMatchNodeSelector, nil, nil
+	}
+
+	// Start with node/pod label based matching to MachineConfig matching when
+	// both the match section and MachineConfigLabels are specified.
+	// Also note the catch-all functionality when "recommend.Match == nil",
+	// we do not want to match the rules in that case unless machineConfigLabels
+	// is undefined.
+	if recommend.Match != nil || recommend.MachineConfigLabels == nil {
+		if path := pc.matchPath(recommend.Match, nodeName); path != nil {
+			return recommendMatchRules, path, nil
+		}
+	}
+
+	if recommend.MachineConfigLabels == nil {
+		// Speed things up, empty labels (used as selectors) match/select nothing.
+		return recommendNoMatchRules, nil, nil
+	}
+
+	pools, err := nodePools()
+	if err != nil {
+		return recommendNoMatchMachineConfigLabels, nil, err
+	}
+
+	// MachineConfigLabels based matching
+	if pc.machineConfigLabelsMatch(recommend.MachineConfigLabels, pools) {
+		return recommendMatchMachineConfigLabels, []string{machineConfigLabelsString(recommend.MachineConfigLabels)}, nil
+	}
+
+	return recommendNoMatchMachineConfigLabels, nil, nil
+}
+
+// calculateProfileFromTuneds calculates a tuned profile for Node nodeName
+// out of Tuned objects tunedList.  See calculateProfile for details.
+func (pc *ProfileCalculator) calculateProfileFromTuneds(nodeName string, tunedList []*tunedv1.Tuned) (ComputedProfile, error) {
+	var err error
+
+	profilesAll := tunedProfiles(tunedList, pc.listers.TunedConfigMaps)
 	recommendAll := TunedRecommend(tunedList)
+	var (
+		pools        []*mcfgv1.MachineConfigPool
+		poolsFetched bool
+	)
+	nodePools := func() ([]*mcfgv1.MachineConfigPool, error) {
+		if poolsFetched {
+			return pools, nil
+		}
+		// Do not retrieve the node object and its pools before they are needed,
+		// fetching the node/pools is often unneeded and would likely have a performance impact.
+		node, err := pc.listers.Nodes.Get(nodeName)
+		if err != nil {
+			return nil, err
+		}
+		pools, err = pc.getPoolsForNode(node)
+		if err != nil {
+			return nil, err
+		}
+		poolsFetched = true
+		return pools, nil
+	}
 	recommendProfile := func(nodeName string, iStart int) (int, RecommendedProfile, error) {
 		var i int
 		for i = iStart; i < len(recommendAll); i++ {
-			var (
-				pools []*mcfgv1.MachineConfigPool
-				node  *corev1.Node
-			)
 			recommend := recommendAll[i]
 
-			// Start with node/pod label based matching to MachineConfig matching when
-			// both the match section and MachineConfigLabels are specified.
-			// Also note the catch-all functionality when "recommend.Match == nil",
-			// we do not want to call profileMatches() in that case unless machineConfigLabels
-			// is undefined.
-			if (recommend.Match != nil || recommend.MachineConfigLabels == nil) && pc.profileMatches(recommend.Match, nodeName) {
+			result, path, err := pc.recommendMatches(recommend, nodeName, nodePools)
+			if err != nil {
+				return i, RecommendedProfile{}, err
+			}
+
+			switch result {
+			case recommendMatchRules:
 				return i, RecommendedProfile{
 					TunedProfileName: *recommend.Profile,
 					Config:           recommend.Operand,
 					Deferred:         recommend.Deferred,
+					Source:           recommend.Source,
+					Priority:         recommend.Priority,
+					MatchPath:        path,
+					Rollout:          recommend.Rollout,
 				}, nil
-			}
-
-			if recommend.MachineConfigLabels == nil {
-				// Speed things up, empty labels (used as selectors) match/select nothing.
-				continue
-			}
-
-			if node == nil {
-				// We did not retrieve the node object from cache yet -- get it and also the pools
-				// for this node.  Do not move this code outside the for loop, fetching the node/pools
-				// is often unneeded and would likely have a performance impact.
-				node, err = pc.listers.Nodes.Get(nodeName)
-				if err != nil {
-					return i, RecommendedProfile{}, err
-				}
-
-				pools, err = pc.getPoolsForNode(node)
-				if err != nil {
-					return i, RecommendedProfile{}, err
-				}
-			}
-
-			// MachineConfigLabels based matching
-			if pc.machineConfigLabelsMatch(recommend.MachineConfigLabels, pools) {
+			case recommendMatchMachineConfigLabels:
 				return i, RecommendedProfile{
 					TunedProfileName: *recommend.Profile,
 					Labels:           recommend.MachineConfigLabels,
 					Config:           recommend.Operand,
 					Deferred:         recommend.Deferred,
+					Source:           recommend.Source,
+					Priority:         recommend.Priority,
+					MatchPath:        path,
+					Rollout:          recommend.Rollout,
 				}, nil
 			}
 		}
@@ -258,7 +357,8 @@ func (pc *ProfileCalculator) calculateProfile(nodeName string) (ComputedProfile,
 		}, fmt.Errorf("the default Tuned CR misses a catch-all profile selection")
 	}
 
-	// Make sure we do not have multiple matching profiles with the same priority.  If so, report a warning.
+	// Make sure we do not have multiple matching profiles with the same priority.  If so, report a conflict.
+	var priorityConflicts []TunedRecommendSource
 	for i := iStop + 1; i < len(recommendAll); i++ {
 		j, recommendedProfileDup, err := recommendProfile(nodeName, i)
 		if err != nil {
@@ -282,8 +382,10 @@ func (pc *ProfileCalculator) calculateProfile(nodeName string) (ComputedProfile,
 		// will be issued by manifests.tunedRenderedProfiles()
 		if *recommendAll[iStop].Priority == *recommendAll[i].Priority {
 			if recommendedProfile.TunedProfileName != recommendedProfileDup.TunedProfileName {
-				klog.Warningf("profiles %s/%s have the same priority %d and match %s; please use a different priority for your custom profiles!",
+				// Reported by the PriorityConflict conditions of the Tuned objects and the ClusterOperator.
+				klog.V(2).Infof("profiles %s/%s have the same priority %d and match %s",
 					recommendedProfile.TunedProfileName, recommendedProfileDup.TunedProfileName, *recommendAll[i].Priority, nodeName)
+				priorityConflicts = append(priorityConflicts, recommendedProfileDup.Source)
 			}
 		} else {
 			// We no longer have recommend rules with the same priority -- do not go through the entire (priority-ordered) list.
@@ -292,11 +394,16 @@ func (pc *ProfileCalculator) calculateProfile(nodeName string) (ComputedProfile,
 	}
 
 	return ComputedProfile{
-		TunedProfileName: recommendedProfile.TunedProfileName,
-		AllProfiles:      profilesAll,
-		Deferred:         recommendedProfile.Deferred,
-		MCLabels:         recommendedProfile.Labels,
-		Operand:          recommendedProfile.Config,
+		TunedProfileName:  recommendedProfile.TunedProfileName,
+		AllProfiles:       profilesAll,
+		Deferred:          recommendedProfile.Deferred,
+		MCLabels:          recommendedProfile.Labels,
+		Operand:           recommendedProfile.Config,
+		Source:            recommendedProfile.Source,
+		Priority:          recommendedProfile.Priority,
+		MatchPath:         recommendedProfile.MatchPath,
+		PriorityConflicts: priorityConflictSources(recommendedProfile.Source, priorityConflicts),
+		Rollout:           recommendedProfile.Rollout,
 	}, err
 }
 
@@ -305,6 +412,10 @@ type HypershiftRecommendedProfile struct {
 	Deferred         util.DeferMode
 	NodePoolName     string
 	Config           tunedv1.OperandConfig
+	Source           TunedRecommendSource
+	Priority         *uint64
+	MatchPath        []string
+	Rollout          *tunedv1.RolloutStrategy
 }
 
 // calculateProfileHyperShift calculates a tuned profile for Node nodeName.
@@ -337,6 +448,7 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 	if err != nil {
 		return ComputedProfile{}, fmt.Errorf("failed to list Tuneds in NodePool %s: %v", nodePoolName, err)
 	}
+	tunedList = tunedsActive(tunedList)
 	defaultTuned, err := pc.listers.TunedResources.Get(tunedv1.TunedDefaultResourceName)
 	if err != nil {
 		return ComputedProfile{
@@ -345,19 +457,28 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 	}
 	tunedList = append(tunedList, defaultTuned)
 
-	profilesAll := tunedProfiles(tunedList)
+	profilesAll := tunedProfiles(tunedList, pc.listers.TunedConfigMaps)
 	recommendAll := TunedRecommend(tunedList)
 	recommendProfile := func(nodeName string, iStart int) (int, HypershiftRecommendedProfile, error) {
 		var i int
 		for i = iStart; i < len(recommendAll); i++ {
 			recommend := recommendAll[i]
 
+			if !pc.nodeSelectorMatches(recommend.NodeSelector, nodeName) {
+				// The Node is not selected by the recommend item's nodeSelector.
+				continue
+			}
+
 			// Start with node/pod label based matching
 			if recommend.Match != nil && pc.profileMatches(recommend.Match, nodeName) {
 				klog.V(3).Infof("calculateProfileHyperShift: node / pod label matching used for node: %s, tunedProfileName: %s, nodePoolName: %s, operand: %v", nodeName, *recommend.Profile, "", recommend.Operand)
 				return i, HypershiftRecommendedProfile{
 					TunedProfileName: *recommend.Profile,
 					Config:           recommend.Operand,
+					Source:           recommend.Source,
+					Priority:         recommend.Priority,
+					MatchPath:        pc.matchPath(recommend.Match, nodeName),
+					Rollout:          recommend.Rollout,
 				}, nil
 			}
 
@@ -368,6 +489,10 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 					return i, HypershiftRecommendedProfile{
 						TunedProfileName: *recommend.Profile,
 						Config:           recommend.Operand,
+						Source:           recommend.Source,
+						Priority:         recommend.Priority,
+						MatchPath:        []string{},
+						Rollout:          recommend.Rollout,
 					}, nil
 				}
 				klog.V(3).Infof("calculateProfileHyperShift: NodePool based matching used for node: %s, tunedProfileName: %s, nodePoolName: %s", nodeName, *recommend.Profile, nodePoolName)
@@ -375,6 +500,10 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 					TunedProfileName: *recommend.Profile,
 					NodePoolName:     nodePoolName,
 					Config:           recommend.Operand,
+					Source:           recommend.Source,
+					Priority:         recommend.Priority,
+					MatchPath:        []string{fmt.Sprintf("nodePool %s", nodePoolName)},
+					Rollout:          recommend.Rollout,
 				}, nil
 			}
 		}
@@ -391,7 +520,8 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 		}, fmt.Errorf("the default Tuned CR misses a catch-all profile selection")
 	}
 
-	// Make sure we do not have multiple matching profiles with the same priority.  If so, report a warning.
+	// Make sure we do not have multiple matching profiles with the same priority.  If so, report a conflict.
+	var priorityConflicts []TunedRecommendSource
 	for i := iStop + 1; i < len(recommendAll); i++ {
 		j, recommendedProfileDup, err := recommendProfile(nodeName, i)
 		if err != nil {
@@ -415,8 +545,10 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 		// will be issued by manifests.tunedRenderedProfiles()
 		if *recommendAll[iStop].Priority == *recommendAll[i].Priority {
 			if recommendedProfile.TunedProfileName != recommendedProfileDup.TunedProfileName {
-				klog.Warningf("profiles %s/%s have the same priority %d and match %s; please use a different priority for your custom profiles!",
+				// Reported by the PriorityConflict conditions of the Tuned objects and the ClusterOperator.
+				klog.V(2).Infof("profiles %s/%s have the same priority %d and match %s",
 					recommendedProfile.TunedProfileName, recommendedProfileDup.TunedProfileName, *recommendAll[i].Priority, nodeName)
+				priorityConflicts = append(priorityConflicts, recommendedProfileDup.Source)
 			}
 		} else {
 			// We no longer have recommend rules with the same priority -- do not go through the entire (priority-ordered) list.
@@ -425,11 +557,16 @@ func (pc *ProfileCalculator) calculateProfileHyperShift(nodeName string) (Comput
 	}
 
 	return ComputedProfile{
-		TunedProfileName: recommendedProfile.TunedProfileName,
-		AllProfiles:      profilesAll,
-		Deferred:         recommendedProfile.Deferred,
-		NodePoolName:     recommendedProfile.NodePoolName,
-		Operand:          recommendedProfile.Config,
+		TunedProfileName:  recommendedProfile.TunedProfileName,
+		AllProfiles:       profilesAll,
+		Deferred:          recommendedProfile.Deferred,
+		NodePoolName:      recommendedProfile.NodePoolName,
+		Operand:           recommendedProfile.Config,
+		Source:            recommendedProfile.Source,
+		Priority:          recommendedProfile.Priority,
+		MatchPath:         recommendedProfile.MatchPath,
+		PriorityConflicts: priorityConflictSources(recommendedProfile.Source, priorityConflicts),
+		Rollout:           recommendedProfile.Rollout,
 	}, err
 }
 
@@ -443,16 +580,7 @@ func (pc *ProfileCalculator) profileMatches(match []tunedv1.TunedMatch, nodeName
 	}
 
 	for _, m := range match {
-		var labelMatches bool
-
-		if m.Type != nil && *m.Type == "pod" { // note the (lower-)case from the API
-			labelMatches = pc.podLabelMatches(m.Label, m.Value, nodeName)
-		} else {
-			// Assume "node" type match; no types other than "node"/"pod" are allowed.
-			// Unspecified m.Type means "node" type match.
-			labelMatches = pc.nodeLabelMatches(m.Label, m.Value, nodeName)
-		}
-		if labelMatches {
+		if pc.labelRuleMatches(m, nodeName) {
 			// AND condition, check if subtree matches too
 			if pc.profileMatches(m.Match, nodeName) {
 				return true
@@ -463,6 +591,71 @@ func (pc *ProfileCalculator) profileMatches(match []tunedv1.TunedMatch, nodeName
 	return false
 }
 
+// labelRuleMatches returns true if Node 'nodeName' fulfills the Node/Pod label
+// rule of TunedMatch 'm' without its subtree, taking its negation into account.
+func (pc *ProfileCalculator) labelRuleMatches(m tunedv1.TunedMatch, nodeName string) bool {
+	var labelMatches bool
+
+	if m.Type != nil && *m.Type == "pod" { // note the (lower-)case from the API
+		labelMatches = pc.podLabelMatches(m.Label, m.Value, nodeName)
+	} else {
+		// Assume "node" type match; no types other than "node"/"pod" are allowed.
+		// Unspecified m.Type means "node" type match.
+		labelMatches = pc.nodeLabelMatches(m.Label, m.Value, nodeName)
+	}
+	if m.Negate != nil && *m.Negate {
+		// Logical NOT, the Node/Pod label (value) must not be present.
+		return !labelMatches
+	}
+
+	return labelMatches
+}
+
+// matchPath returns the human-readable match rules of 'match' on the first path of
+// the match tree matching Node 'nodeName' like profileMatches does.  Returns nil if
+// no path matches and an empty slice for an empty (catch-all) match tree.
+func (pc *ProfileCalculator) matchPath(match []tunedv1.TunedMatch, nodeName string) []string {
+	if len(match) == 0 {
+		return []string{}
+	}
+
+	for _, m := range match {
+		if !pc.labelRuleMatches(m, nodeName) {
+			continue
+		}
+		if path := pc.matchPath(m.Match, nodeName); path != nil {
+			return append([]string{matchString(m)}, path...)
+		}
+	}
+
+	return nil
+}
+
+// matchString returns a human-readable form of the match rule 'm' without its subtree.
+func matchString(m tunedv1.TunedMatch) string {
+	kind := "node"
+	if m.Type != nil && *m.Type == "pod" {
+		kind = "pod"
+	}
+	negate := ""
+	if m.Negate != nil && *m.Negate {
+		negate = "NOT "
+	}
+	if m.Label == nil {
+		return fmt.Sprintf("%sany %s", negate, kind)
+	}
+	if m.Value == nil {
+		return fmt.Sprintf("%s%s label %s", negate, kind, *m.Label)
+	}
+	return fmt.Sprintf("%s%s label %s=%s", negate, kind, *m.Label, *m.Value)
+}
+
+// machineConfigLabelsString returns a human-readable form of the MachineConfig labels
+// 'machineConfigLabels' sorted by their keys.
+func machineConfigLabelsString(machineConfigLabels map[string]string) string {
+	return "machineConfigLabels " + labels.Set(machineConfigLabels).String()
+}
+
 // nodeLabelMatches returns true if Node label's 'mNodeLabel' value 'mNodeLabelValue'
 // matches any of the Node labels in the ProfileCalculator internal data structures
 // for Node of the name 'mNodeName'.
@@ -486,6 +679,25 @@ func (pc *ProfileCalculator) nodeLabelMatches(mNodeLabel *string, mNodeLabelValu
 	return false
 }
 
+// nodeSelectorMatches returns true if Node labels in the ProfileCalculator internal
+// data structures for Node of the name 'mNodeName' match label selector 'selector'.
+// Undefined 'selector' matches all Nodes.
+func (pc *ProfileCalculator) nodeSelectorMatches(selector *metav1.LabelSelector, mNodeName string) bool {
+	if selector == nil {
+		// Undefined node selector matches
+		return true
+	}
+
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		// Reported by the Valid condition of the Tuned object, do not spam the logs.
+		klog.V(2).Infof("invalid nodeSelector %v: %v", selector, err)
+		return false
+	}
+
+	return s.Matches(labels.Set(pc.state.nodeLabels[mNodeName]))
+}
+
 // podLabelMatches returns true if Pod label's 'mPodLabel' value 'mPodLabelValue'
 // matches any of the Pod labels in the ProfileCalculator internal data structures
 // for any Pod associated with Node of the name 'mNodeName'.
@@ -581,6 +793,7 @@ func (pc *ProfileCalculator) podLabelsGet(podNamespace, podName string) (string,
 func (pc *ProfileCalculator) nodeRemove(nodeName string) {
 	// Delete all structures related to nodeName in nodeLabels
 	delete(pc.state.nodeLabels, nodeName)
+	delete(pc.state.nodeAnnotations, nodeName)
 
 	// Delete all data structures related to nodeName in podLabels
 	delete(pc.state.podLabels, nodeName)
@@ -665,14 +878,15 @@ func (pc *ProfileCalculator) tunedUsesPodLabels(match []tunedv1.TunedMatch) bool
 	return false
 }
 
-// tunedsUseNodeLabels returns true if any of the Tuned CRs uses Node labels.
+// tunedsUseNodeLabels returns true if any of the Tuned CRs uses Node labels,
+// either in the recommend items or in the node-specific variables of TuneD profiles.
 func (pc *ProfileCalculator) tunedsUseNodeLabels(tunedSlice []*tunedv1.Tuned) bool {
 	for _, recommend := range TunedRecommend(tunedSlice) {
-		if pc.tunedUsesNodeLabels(recommend.Match) {
+		if recommend.NodeSelector != nil || pc.tunedUsesNodeLabels(recommend.Match) {
 			return true
 		}
 	}
-	return false
+	return nodeVariablesUsed(tunedProfiles(tunedSlice, pc.listers.TunedConfigMaps))
 }
 
 // tunedsUsePodLabels returns true if any of the Tuned CRs uses Pod labels.
@@ -692,9 +906,25 @@ func (pc *ProfileCalculator) getNodePoolNameForNode(node *corev1.Node) (string,
 	return nodePoolName, nil
 }
 
+// tunedsActive returns a subset of Tuned objects 'tunedSlice' which are not
+// in the dry-run mode, i.e. can affect the Profiles.
+func tunedsActive(tunedSlice []*tunedv1.Tuned) []*tunedv1.Tuned {
+	active := make([]*tunedv1.Tuned, 0, len(tunedSlice))
+
+	for _, tuned := range tunedSlice {
+		if util.HasDryRunAnnotation(tuned.Annotations) {
+			continue
+		}
+		active = append(active, tuned)
+	}
+
+	return active
+}
+
 // tunedProfiles returns a name-sorted TunedProfile slice out of
-// a slice of Tuned objects.
-func tunedProfiles(tunedSlice []*tunedv1.Tuned) []tunedv1.TunedProfile {
+// a slice of Tuned objects.  The data of TuneD profiles sourced from
+// ConfigMaps are resolved using the ConfigMap lister 'configMaps'.
+func tunedProfiles(tunedSlice []*tunedv1.Tuned, configMaps kcorelisters.ConfigMapNamespaceLister) []tunedv1.TunedProfile {
 	tunedProfiles := []tunedv1.TunedProfile{}
 	m := map[string]tunedv1.TunedProfile{}
 
@@ -703,6 +933,14 @@ func tunedProfiles(tunedSlice []*tunedv1.Tuned) []tunedv1.TunedProfile {
 			continue
 		}
 		for _, v := range tuned.Spec.Profile {
+			if v.Name != nil && v.Data == nil && v.DataFrom != nil {
+				data, err := tunedProfileDataFrom(v.DataFrom, configMaps)
+				if err != nil {
+					klog.Errorf("failed to resolve data of profile %s in Tuned CR %q: %v", *v.Name, tuned.Name, err)
+					continue
+				}
+				v = tunedv1.TunedProfile{Name: v.Name, Data: &data}
+			}
 			if v.Name == nil || v.Data == nil {
 				continue
 			}
@@ -733,6 +971,21 @@ func tunedProfiles(tunedSlice []*tunedv1.Tuned) []tunedv1.TunedProfile {
 type TunedRecommendInfo struct {
 	tunedv1.TunedRecommend
 	Deferred util.DeferMode
+	Source   TunedRecommendSource
+}
+
+// TunedRecommendSource identifies the recommend item of a Tuned object
+// a TunedRecommendInfo was created from.
+type TunedRecommendSource struct {
+	// Name of the Tuned object.
+	TunedName string
+	// Index of the item in the Tuned object's spec.recommend list.
+	Index int
+}
+
+// String returns a human-readable reference to the recommend item.
+func (s TunedRecommendSource) String() string {
+	return fmt.Sprintf("%s/recommend[%d]", s.TunedName, s.Index)
 }
 
 // TunedRecommend returns a priority-sorted TunedRecommend slice out of
@@ -749,10 +1002,14 @@ func TunedRecommend(tunedSlice []*tunedv1.Tuned) []TunedRecommendInfo {
 	})
 
 	for _, tuned := range tunedSlice {
-		for _, recommend := range tuned.Spec.Recommend {
+		for i, recommend := range tuned.Spec.Recommend {
 			recommendAll = append(recommendAll, TunedRecommendInfo{
 				TunedRecommend: recommend,
 				Deferred:       util.GetDeferredUpdateAnnotation(tuned.Annotations),
+				Source: TunedRecommendSource{
+					TunedName: tuned.Name,
+					Index:     i,
+				},
 			})
 		}
 	}
@@ -6,12 +6,16 @@ import (
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	kcorelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/utils/ptr"
 
 	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoclient "github.com/openshift/cluster-node-tuning-operator/pkg/client"
 )
 
 func tunedProfileToString(tunedProfile tunedv1.TunedProfile) string {
@@ -123,7 +127,7 @@ func TestTunedProfiles(t *testing.T) {
 	)
 
 	for i, tc := range tests {
-		tunedProfilesSorted := tunedProfiles(tc.input)
+		tunedProfilesSorted := tunedProfiles(tc.input, nil)
 
 		if !reflect.DeepEqual(tc.expectedOutput, tunedProfilesSorted) {
 			t.Errorf(
@@ -135,3 +139,361 @@ func TestTunedProfiles(t *testing.T) {
 		}
 	}
 }
+
+func TestTunedProfilesDataFrom(t *testing.T) {
+	const namespace = "openshift-cluster-node-tuning-operator"
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	err := indexer.Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "profiles", Namespace: namespace},
+		Data:       map[string]string{"a": "[main]\nsummary=a\n"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configMaps := kcorelisters.NewConfigMapLister(indexer).ConfigMaps(namespace)
+
+	newTuned := func(profiles ...tunedv1.TunedProfile) []*tunedv1.Tuned {
+		return []*tunedv1.Tuned{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "custom", Namespace: namespace},
+				Spec:       tunedv1.TunedSpec{Profile: profiles},
+			},
+		}
+	}
+
+	tests := []struct {
+		input          []*tunedv1.Tuned
+		expectedOutput []tunedv1.TunedProfile
+	}{
+		{
+			input: newTuned(
+				tunedv1.TunedProfile{
+					Name:     ptr.To("a"),
+					DataFrom: &tunedv1.TunedProfileDataSource{ConfigMap: "profiles", Key: "a"},
+				},
+				tunedv1.TunedProfile{
+					Name: ptr.To("b"),
+					Data: ptr.To("[main]\nsummary=b\n"),
+				},
+			),
+			expectedOutput: []tunedv1.TunedProfile{
+				{
+					Name: ptr.To("a"),
+					Data: ptr.To("[main]\nsummary=a\n"),
+				},
+				{
+					Name: ptr.To("b"),
+					Data: ptr.To("[main]\nsummary=b\n"),
+				},
+			},
+		},
+		{
+			input: newTuned(
+				tunedv1.TunedProfile{
+					Name:     ptr.To("a"),
+					DataFrom: &tunedv1.TunedProfileDataSource{ConfigMap: "profiles", Key: "missing"},
+				},
+				tunedv1.TunedProfile{
+					Name:     ptr.To("b"),
+					DataFrom: &tunedv1.TunedProfileDataSource{ConfigMap: "missing", Key: "b"},
+				},
+			),
+			expectedOutput: []tunedv1.TunedProfile{},
+		},
+	}
+
+	for i, tc := range tests {
+		tunedProfilesSorted := tunedProfiles(tc.input, configMaps)
+
+		if !reflect.DeepEqual(tc.expectedOutput, tunedProfilesSorted) {
+			t.Errorf(
+				"failed test case %d:\n\twant:\n%s\n\thave:\n%s",
+				i+1,
+				tunedProfilesToString(tc.expectedOutput),
+				tunedProfilesToString(tunedProfilesSorted),
+			)
+		}
+	}
+
+	if !tunedsReferenceConfigMap(tests[0].input, "profiles") {
+		t.Errorf("want Tuned referencing ConfigMap profiles")
+	}
+	if tunedsReferenceConfigMap(tests[0].input, "other") {
+		t.Errorf("want no Tuned referencing ConfigMap other")
+	}
+}
+
+func TestNodeSelectorMatches(t *testing.T) {
+	pc := NewProfileCalculator(nil, nil)
+	pc.state.nodeLabels["node1"] = map[string]string{
+		"topology.kubernetes.io/zone":    "zone-a",
+		"node-role.kubernetes.io/worker": "",
+	}
+	pc.state.nodeLabels["node2"] = map[string]string{
+		"topology.kubernetes.io/zone":    "zone-b",
+		"node-role.kubernetes.io/infra":  "",
+		"node-role.kubernetes.io/worker": "",
+	}
+
+	// Nodes in zone-a or zone-b, but not infra Nodes.
+	zoneNotInfra := &metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{
+			{
+				Key:      "topology.kubernetes.io/zone",
+				Operator: metav1.LabelSelectorOpIn,
+				Values:   []string{"zone-a", "zone-b"},
+			},
+			{
+				Key:      "node-role.kubernetes.io/infra",
+				Operator: metav1.LabelSelectorOpDoesNotExist,
+			},
+		},
+	}
+
+	tests := []struct {
+		selector       *metav1.LabelSelector
+		nodeName       string
+		expectedOutput bool
+	}{
+		{
+			selector:       nil,
+			nodeName:       "node1",
+			expectedOutput: true,
+		},
+		{
+			selector:       zoneNotInfra,
+			nodeName:       "node1",
+			expectedOutput: true,
+		},
+		{
+			selector:       zoneNotInfra,
+			nodeName:       "node2",
+			expectedOutput: false,
+		},
+		{
+			selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"topology.kubernetes.io/zone": "zone-b"},
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "topology.kubernetes.io/zone",
+						Operator: metav1.LabelSelectorOpNotIn,
+						Values:   []string{"zone-a"},
+					},
+					{
+						Key:      "node-role.kubernetes.io/worker",
+						Operator: metav1.LabelSelectorOpExists,
+					},
+				},
+			},
+			nodeName:       "node2",
+			expectedOutput: true,
+		},
+		{
+			// Invalid selector matches nothing.
+			selector: &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{
+					{
+						Key:      "topology.kubernetes.io/zone",
+						Operator: "Unknown",
+					},
+				},
+			},
+			nodeName:       "node1",
+			expectedOutput: false,
+		},
+		{
+			// Unknown Node matches only selectors not requiring any labels.
+			selector:       zoneNotInfra,
+			nodeName:       "node3",
+			expectedOutput: false,
+		},
+	}
+
+	for i, tc := range tests {
+		matches := pc.nodeSelectorMatches(tc.selector, tc.nodeName)
+
+		if matches != tc.expectedOutput {
+			t.Errorf(
+				"failed test case %d:\n\twant: %v\n\thave: %v",
+				i+1,
+				tc.expectedOutput,
+				matches,
+			)
+		}
+	}
+}
+
+func TestProfileMatchesNegate(t *testing.T) {
+	pc := NewProfileCalculator(nil, nil)
+	pc.state.nodeLabels["node1"] = map[string]string{
+		"node-role.kubernetes.io/worker": "",
+		"topology.kubernetes.io/zone":    "zone-a",
+	}
+	pc.state.nodeLabels["node2"] = map[string]string{
+		"node-role.kubernetes.io/worker": "",
+		"node-role.kubernetes.io/infra":  "",
+	}
+	pc.state.nodeLabels["node3"] = map[string]string{
+		"node-role.kubernetes.io/master": "",
+	}
+	pc.state.nodeLabels["node4"] = map[string]string{
+		"node-role.kubernetes.io/edge": "",
+	}
+	pc.state.podLabels["node1"] = map[string]map[string]string{
+		"default/db": {"app": "db"},
+	}
+
+	nodeNames := []string{"node1", "node2", "node3", "node4"}
+	tests := []struct {
+		match          []tunedv1.TunedMatch
+		expectedOutput []bool // for nodeNames
+	}{
+		{
+			// NOT infra
+			match: []tunedv1.TunedMatch{
+				{Label: ptr.To("node-role.kubernetes.io/infra"), Negate: ptr.To(true)},
+			},
+			expectedOutput: []bool{true, false, true, true},
+		},
+		{
+			// worker AND NOT infra
+			match: []tunedv1.TunedMatch{
+				{
+					Label: ptr.To("node-role.kubernetes.io/worker"),
+					Match: []tunedv1.TunedMatch{
+						{Label: ptr.To("node-role.kubernetes.io/infra"), Negate: ptr.To(true)},
+					},
+				},
+			},
+			expectedOutput: []bool{true, false, false, false},
+		},
+		{
+			// master OR (worker AND NOT pod app=db)
+			match: []tunedv1.TunedMatch{
+				{Label: ptr.To("node-role.kubernetes.io/master")},
+				{
+					Label: ptr.To("node-role.kubernetes.io/worker"),
+					Match: []tunedv1.TunedMatch{
+						{Label: ptr.To("app"), Value: ptr.To("db"), Type: ptr.To("pod"), Negate: ptr.To(true)},
+					},
+				},
+			},
+			expectedOutput: []bool{false, true, true, false},
+		},
+		{
+			// NOT worker AND NOT master
+			match: []tunedv1.TunedMatch{
+				{
+					Label:  ptr.To("node-role.kubernetes.io/worker"),
+					Negate: ptr.To(true),
+					Match: []tunedv1.TunedMatch{
+						{Label: ptr.To("node-role.kubernetes.io/master"), Negate: ptr.To(true)},
+					},
+				},
+			},
+			expectedOutput: []bool{false, false, false, true},
+		},
+		{
+			// NOT zone=zone-a; Nodes without the label match
+			match: []tunedv1.TunedMatch{
+				{Label: ptr.To("topology.kubernetes.io/zone"), Value: ptr.To("zone-a"), Negate: ptr.To(true)},
+			},
+			expectedOutput: []bool{false, true, true, true},
+		},
+		{
+			// NOT any pod with label app; explicit negate=false does not negate
+			match: []tunedv1.TunedMatch{
+				{
+					Label:  ptr.To("app"),
+					Type:   ptr.To("pod"),
+					Negate: ptr.To(true),
+					Match: []tunedv1.TunedMatch{
+						{Label: ptr.To("node-role.kubernetes.io/worker"), Negate: ptr.To(false)},
+					},
+				},
+			},
+			expectedOutput: []bool{false, true, false, false},
+		},
+	}
+
+	for i, tc := range tests {
+		for j, nodeName := range nodeNames {
+			matches := pc.profileMatches(tc.match, nodeName)
+			if matches != tc.expectedOutput[j] {
+				t.Errorf("failed test case %d (%s):\n\twant: %v\n\thave: %v", i+1, nodeName, tc.expectedOutput[j], matches)
+			}
+			if path := pc.matchPath(tc.match, nodeName); (path != nil) != matches {
+				t.Errorf("failed test case %d (%s): matchPath %v disagrees with profileMatches %v", i+1, nodeName, path, matches)
+			}
+		}
+	}
+
+	want := []string{"node label node-role.kubernetes.io/worker", "NOT node label node-role.kubernetes.io/infra"}
+	if have := pc.matchPath(tests[1].match, "node1"); !reflect.DeepEqual(have, want) {
+		t.Errorf("want match path %v, have: %v", want, have)
+	}
+}
+
+func TestCalculateProfileDryRun(t *testing.T) {
+	pc := NewProfileCalculator(&ntoclient.Listers{}, nil)
+	pc.state.nodeLabels["node1"] = map[string]string{"node-role.kubernetes.io/worker": ""}
+
+	newTuned := func(name, profile string, priority uint64, dryRun bool) *tunedv1.Tuned {
+		tuned := &tunedv1.Tuned{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "openshift-cluster-node-tuning-operator",
+			},
+			Spec: tunedv1.TunedSpec{
+				Profile: []tunedv1.TunedProfile{
+					{
+						Name: ptr.To(profile),
+						Data: ptr.To("[main]"),
+					},
+				},
+				Recommend: []tunedv1.TunedRecommend{
+					{
+						Profile:  ptr.To(profile),
+						Priority: ptr.To(priority),
+						Match: []tunedv1.TunedMatch{
+							{
+								Label: ptr.To("node-role.kubernetes.io/worker"),
+							},
+						},
+					},
+				},
+			},
+		}
+		if dryRun {
+			tuned.Annotations = map[string]string{tunedv1.TunedDryRun: "true"}
+		}
+		return tuned
+	}
+
+	tunedList := []*tunedv1.Tuned{
+		newTuned("default", "openshift-node", 30, false),
+		newTuned("custom", "custom", 20, true),
+	}
+
+	active := tunedsActive(tunedList)
+	if len(active) != 1 || active[0].Name != "default" {
+		t.Fatalf("failed to filter out Tuned in the dry-run mode: %v", active)
+	}
+
+	computed, err := pc.calculateProfileFromTuneds("node1", active)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if computed.TunedProfileName != "openshift-node" {
+		t.Errorf("want TuneD profile openshift-node, have: %s", computed.TunedProfileName)
+	}
+
+	computed, err = pc.calculateProfileFromTuneds("node1", tunedList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if computed.TunedProfileName != "custom" || computed.Source.TunedName != "custom" {
+		t.Errorf("want TuneD profile custom from Tuned custom, have: %s from Tuned %s", computed.TunedProfileName, computed.Source.TunedName)
+	}
+}
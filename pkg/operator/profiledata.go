@@ -0,0 +1,56 @@
+package operator
+
+import (
+	"fmt"
+
+	kcorelisters "k8s.io/client-go/listers/core/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+// tunedProfileDataFrom returns the TuneD profile data referenced by 'dataFrom'
+// using the ConfigMap lister 'configMaps'.
+func tunedProfileDataFrom(dataFrom *tunedv1.TunedProfileDataSource, configMaps kcorelisters.ConfigMapNamespaceLister) (string, error) {
+	if configMaps == nil {
+		return "", fmt.Errorf("ConfigMap %s is not available", dataFrom.ConfigMap)
+	}
+
+	cm, err := configMaps.Get(dataFrom.ConfigMap)
+	if err != nil {
+		return "", fmt.Errorf("failed to get ConfigMap %s: %v", dataFrom.ConfigMap, err)
+	}
+
+	data, ok := cm.Data[dataFrom.Key]
+	if !ok {
+		return "", fmt.Errorf("failed to find key %s in ConfigMap %s", dataFrom.Key, dataFrom.ConfigMap)
+	}
+
+	return data, nil
+}
+
+// tunedProfileData returns the data of TuneD profile 'profile', either inline or
+// sourced from a ConfigMap using the ConfigMap lister 'configMaps'.
+func tunedProfileData(profile tunedv1.TunedProfile, configMaps kcorelisters.ConfigMapNamespaceLister) (string, error) {
+	if profile.DataFrom != nil {
+		return tunedProfileDataFrom(profile.DataFrom, configMaps)
+	}
+	if profile.Data == nil {
+		return "", fmt.Errorf("no data")
+	}
+
+	return *profile.Data, nil
+}
+
+// tunedsReferenceConfigMap returns true if any of the TuneD profiles of Tuned
+// objects 'tunedList' is sourced from ConfigMap 'name'.
+func tunedsReferenceConfigMap(tunedList []*tunedv1.Tuned, name string) bool {
+	for _, tuned := range tunedList {
+		for _, profile := range tuned.Spec.Profile {
+			if profile.DataFrom != nil && profile.DataFrom.ConfigMap == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,244 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+)
+
+const (
+	// revisionHistoryLimit is the number of ControllerRevisions kept for every Tuned
+	// object and for the rendered profile sets not referenced by any Profile.
+	revisionHistoryLimit = 10
+)
+
+// revisionHash returns a hash of JSON-encoded data 'data' suitable for use
+// in ControllerRevision names.  A non-zero 'collisionCount' changes the hash
+// for data whose hash collides with a different ControllerRevision.
+func revisionHash(data []byte, collisionCount int32) string {
+	h := fnv.New32a()
+	h.Write(data)
+	if collisionCount > 0 {
+		b := make([]byte, 4)
+		binary.LittleEndian.PutUint32(b, uint32(collisionCount))
+		h.Write(b)
+	}
+	return rand.SafeEncodeString(fmt.Sprint(h.Sum32()))
+}
+
+// renderedProfileData returns the JSON-encoded rendered profile set of the computed
+// profile 'computed'.  The cloud provider name and the Node labels and annotations
+// referenced by the TuneD profiles are not part of the rendered profile set, they
+// are Node-specific.
+func renderedProfileData(computed ComputedProfile) ([]byte, error) {
+	data, err := json.Marshal(tunedv1.ProfileSpec{
+		Config: tunedv1.ProfileConfig{
+			TunedProfile:       computed.TunedProfileName,
+			Debug:              computed.Operand.Debug,
+			Verbosity:          computed.Operand.Verbosity,
+			TuneDConfig:        computed.Operand.TuneDConfig,
+			RollbackOnDegraded: computed.Operand.RollbackOnDegraded,
+			VerifyInterval:     computed.Operand.VerifyInterval,
+		},
+		Profile: computed.UnexpandedProfiles,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode rendered profile set: %v", err)
+	}
+	return data, nil
+}
+
+// syncTunedRevisions records a ControllerRevision with a snapshot of every
+// Tuned object whose current specification has not been recorded yet.
+func (c *Controller) syncTunedRevisions() error {
+	tunedList, err := c.listers.TunedResources.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list Tuned: %v", err)
+	}
+
+	tuneds := map[string]bool{}
+	for _, tuned := range tunedList {
+		tuneds[tuned.Name] = true
+
+		data, err := json.Marshal(tuned.Spec)
+		if err != nil {
+			return fmt.Errorf("failed to encode Tuned %s: %v", tuned.Name, err)
+		}
+
+		selector := labels.SelectorFromSet(labels.Set{
+			tunedv1.TunedRevisionTypeLabelKey:  tunedv1.TunedRevisionTypeTuned,
+			tunedv1.TunedRevisionTunedLabelKey: tuned.Name,
+		})
+		revisionMf := &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ntoconfig.WatchNamespace(),
+				Labels: map[string]string{
+					tunedv1.TunedRevisionTypeLabelKey:  tunedv1.TunedRevisionTypeTuned,
+					tunedv1.TunedRevisionTunedLabelKey: tuned.Name,
+				},
+				OwnerReferences: getDefaultTunedRefs(tuned),
+			},
+			Data: runtime.RawExtension{Raw: data},
+		}
+		name, err := c.syncRevision(revisionMf, tuned.Name, selector, true)
+		if err != nil {
+			return err
+		}
+		c.tunedRevisions[tuned.Name] = name
+	}
+
+	for tunedName := range c.tunedRevisions {
+		if !tuneds[tunedName] {
+			// Revisions of deleted Tuned objects are garbage-collected via their owner reference.
+			delete(c.tunedRevisions, tunedName)
+		}
+	}
+
+	return nil
+}
+
+// syncRenderedRevision records a ControllerRevision with the rendered profile set
+// of the computed profile 'computed' unless already recorded.  Returns the name of
+// the ControllerRevision.
+func (c *Controller) syncRenderedRevision(tuned *tunedv1.Tuned, computed ComputedProfile) (string, error) {
+	data, err := renderedProfileData(computed)
+	if err != nil {
+		return "", err
+	}
+
+	revisionMf := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ntoconfig.WatchNamespace(),
+			Labels: map[string]string{
+				tunedv1.TunedRevisionTypeLabelKey: tunedv1.TunedRevisionTypeRendered,
+			},
+			OwnerReferences: getDefaultTunedRefs(tuned),
+		},
+		Data: runtime.RawExtension{Raw: data},
+	}
+	if tunedRevision, ok := c.tunedRevisions[computed.Source.TunedName]; ok {
+		revisionMf.Annotations = map[string]string{tunedv1.TunedRevisionTunedAnnotationKey: tunedRevision}
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{tunedv1.TunedRevisionTypeLabelKey: tunedv1.TunedRevisionTypeRendered})
+	// Several rendered profile sets are in use at the same time, keep their order of creation.
+	return c.syncRevision(revisionMf, tunedv1.TunedRevisionTypeRendered, selector, false)
+}
+
+// syncRevision records ControllerRevision 'revisionMf' named by 'prefix' and
+// the hash of its data as the newest revision among the ControllerRevisions
+// selected by 'selector' unless already recorded.  If 'renumber' is true,
+// revisions which already exist are renumbered to become the newest.  Old
+// revisions over the history limit are pruned.  Returns the name of the
+// ControllerRevision.
+func (c *Controller) syncRevision(revisionMf *appsv1.ControllerRevision, prefix string, selector labels.Selector, renumber bool) (string, error) {
+	revisionClient := c.clients.Apps.ControllerRevisions(ntoconfig.WatchNamespace())
+
+	revisions, err := c.listers.ControllerRevisions.List(selector)
+	if err != nil {
+		return "", fmt.Errorf("failed to list ControllerRevisions: %v", err)
+	}
+	var maxRevision int64
+	for _, revision := range revisions {
+		if revision.Revision > maxRevision {
+			maxRevision = revision.Revision
+		}
+	}
+
+	for collisionCount := int32(0); ; collisionCount++ {
+		name := prefix + "-" + revisionHash(revisionMf.Data.Raw, collisionCount)
+
+		existing, err := c.listers.ControllerRevisions.Get(name)
+		if errors.IsNotFound(err) {
+			revisionMf.Name = name
+			revisionMf.Revision = maxRevision + 1
+			klog.V(2).Infof("syncRevision(): creating ControllerRevision %s (revision %d)", revisionMf.Name, revisionMf.Revision)
+			_, err = revisionClient.Create(context.TODO(), revisionMf, metav1.CreateOptions{})
+			if err == nil {
+				return name, c.pruneRevisions(append(revisions, revisionMf))
+			}
+			if !errors.IsAlreadyExists(err) {
+				return "", fmt.Errorf("failed to create ControllerRevision %s: %v", name, err)
+			}
+			// Created, but not seen by the informer yet.
+			existing, err = revisionClient.Get(context.TODO(), name, metav1.GetOptions{})
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to get ControllerRevision %s: %v", name, err)
+		}
+
+		if !bytes.Equal(existing.Data.Raw, revisionMf.Data.Raw) {
+			klog.V(2).Infof("syncRevision(): ControllerRevision %s holds different data, collision count %d", name, collisionCount+1)
+			continue
+		}
+
+		if renumber && existing.Revision < maxRevision {
+			// Going back to a previous revision, make it the newest one.
+			existing = existing.DeepCopy()
+			existing.Revision = maxRevision + 1
+			klog.V(2).Infof("syncRevision(): renumbering ControllerRevision %s (revision %d)", existing.Name, existing.Revision)
+			_, err = revisionClient.Update(context.TODO(), existing, metav1.UpdateOptions{})
+			if err != nil {
+				return "", fmt.Errorf("failed to update ControllerRevision %s: %v", existing.Name, err)
+			}
+			for i := range revisions {
+				if revisions[i].Name == existing.Name {
+					revisions[i] = existing
+				}
+			}
+			return name, c.pruneRevisions(revisions)
+		}
+
+		return name, nil
+	}
+}
+
+// pruneRevisions deletes the oldest ControllerRevisions from 'revisions' over
+// the history limit.  ControllerRevisions of rendered profile sets referenced
+// by Profiles are never deleted.
+func (c *Controller) pruneRevisions(revisions []*appsv1.ControllerRevision) error {
+	if len(revisions) <= revisionHistoryLimit {
+		return nil
+	}
+
+	profileList, err := c.listers.TunedProfiles.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list Profiles: %v", err)
+	}
+	referenced := map[string]bool{}
+	for _, profile := range profileList {
+		referenced[profile.Annotations[tunedv1.TunedRevisionAnnotationKey]] = true
+		referenced[profile.Status.Revision] = true
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Revision > revisions[j].Revision
+	})
+	for _, revision := range revisions[revisionHistoryLimit:] {
+		if referenced[revision.Name] {
+			continue
+		}
+		klog.V(2).Infof("pruneRevisions(): deleting ControllerRevision %s (revision %d)", revision.Name, revision.Revision)
+		err := c.clients.Apps.ControllerRevisions(ntoconfig.WatchNamespace()).Delete(context.TODO(), revision.Name, metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete ControllerRevision %s: %v", revision.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	kappslisters "k8s.io/client-go/listers/apps/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoclient "github.com/openshift/cluster-node-tuning-operator/pkg/client"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+)
+
+func TestRenderedProfileData(t *testing.T) {
+	computed := ComputedProfile{
+		TunedProfileName: "openshift-node",
+		UnexpandedProfiles: []tunedv1.TunedProfile{
+			{Name: ptr.To("openshift-node"), Data: ptr.To("[main]\ninclude=openshift\n[sysctl]\nvm.nr_hugepages=${node.label:hugepages}")},
+		},
+		Source: TunedRecommendSource{TunedName: "default", Index: 1},
+	}
+	data, err := renderedProfileData(computed)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		computed ComputedProfile
+		same     bool
+	}{
+		{
+			// The recommend item selecting the profile set is not part of the rendered profile set.
+			computed: ComputedProfile{
+				TunedProfileName:   computed.TunedProfileName,
+				UnexpandedProfiles: computed.UnexpandedProfiles,
+				Source:             TunedRecommendSource{TunedName: "custom", Index: 0},
+			},
+			same: true,
+		},
+		{
+			// The Node labels expanded into the TuneD profiles are not part of the rendered profile set.
+			computed: ComputedProfile{
+				TunedProfileName: computed.TunedProfileName,
+				AllProfiles: []tunedv1.TunedProfile{
+					{Name: ptr.To("openshift-node"), Data: ptr.To("[main]\ninclude=openshift\n[sysctl]\nvm.nr_hugepages=16")},
+				},
+				UnexpandedProfiles: computed.UnexpandedProfiles,
+			},
+			same: true,
+		},
+		{
+			// Different TuneD profile data.
+			computed: ComputedProfile{
+				TunedProfileName: computed.TunedProfileName,
+				UnexpandedProfiles: []tunedv1.TunedProfile{
+					{Name: ptr.To("openshift-node"), Data: ptr.To("[main]\ninclude=openshift\n[sysctl]\nvm.swappiness=10")},
+				},
+			},
+			same: false,
+		},
+		{
+			// Different operand configuration.
+			computed: ComputedProfile{
+				TunedProfileName:   computed.TunedProfileName,
+				UnexpandedProfiles: computed.UnexpandedProfiles,
+				Operand:            tunedv1.OperandConfig{Debug: true},
+			},
+			same: false,
+		},
+	}
+
+	for i, tc := range tests {
+		have, err := renderedProfileData(tc.computed)
+		if err != nil {
+			t.Errorf("failed test case %d: %v", i+1, err)
+			continue
+		}
+		if bytes.Equal(have, data) != tc.same {
+			t.Errorf("failed test case %d:\n\twant same rendered profile set: %v\n\thave: %s vs. %s", i+1, tc.same, have, data)
+		}
+	}
+}
+
+func TestSyncRevision(t *testing.T) {
+	const prefix = tunedv1.TunedRevisionTypeRendered
+	data := []byte(`{"config":{"tunedProfile":"openshift-node"}}`)
+	other := []byte(`{"config":{"tunedProfile":"openshift-control-plane"}}`)
+	selector := labels.SelectorFromSet(labels.Set{tunedv1.TunedRevisionTypeLabelKey: tunedv1.TunedRevisionTypeRendered})
+
+	revision := func(collisionCount int32, data []byte) *appsv1.ControllerRevision {
+		return &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefix + "-" + revisionHash(data, collisionCount),
+				Namespace: ntoconfig.WatchNamespace(),
+				Labels:    map[string]string{tunedv1.TunedRevisionTypeLabelKey: tunedv1.TunedRevisionTypeRendered},
+			},
+			Data:     runtime.RawExtension{Raw: data},
+			Revision: 1,
+		}
+	}
+	// A ControllerRevision whose name collides with the revision of 'data'.
+	collision := revision(0, other)
+	collision.Name = prefix + "-" + revisionHash(data, 0)
+
+	tests := []struct {
+		cached   []*appsv1.ControllerRevision
+		existing []*appsv1.ControllerRevision
+		want     string
+		created  bool
+	}{
+		{
+			// Not recorded yet.
+			want:    prefix + "-" + revisionHash(data, 0),
+			created: true,
+		},
+		{
+			// Already recorded.
+			cached:   []*appsv1.ControllerRevision{revision(0, data)},
+			existing: []*appsv1.ControllerRevision{revision(0, data)},
+			want:     prefix + "-" + revisionHash(data, 0),
+		},
+		{
+			// Recorded, but not seen by the informer yet.
+			existing: []*appsv1.ControllerRevision{revision(0, data)},
+			want:     prefix + "-" + revisionHash(data, 0),
+		},
+		{
+			// Hash collision with a ControllerRevision in the cache.
+			cached:   []*appsv1.ControllerRevision{collision},
+			existing: []*appsv1.ControllerRevision{collision},
+			want:     prefix + "-" + revisionHash(data, 1),
+			created:  true,
+		},
+		{
+			// Hash collision with a ControllerRevision not seen by the informer yet.
+			existing: []*appsv1.ControllerRevision{collision},
+			want:     prefix + "-" + revisionHash(data, 1),
+			created:  true,
+		},
+	}
+
+	for i, tc := range tests {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		for _, revision := range tc.cached {
+			if err := indexer.Add(revision); err != nil {
+				t.Fatal(err)
+			}
+		}
+		objects := []runtime.Object{}
+		for _, revision := range tc.existing {
+			objects = append(objects, revision)
+		}
+		c := &Controller{
+			listers: &ntoclient.Listers{
+				ControllerRevisions: kappslisters.NewControllerRevisionLister(indexer).ControllerRevisions(ntoconfig.WatchNamespace()),
+			},
+			clients: &ntoclient.Clients{
+				Apps: fake.NewSimpleClientset(objects...).AppsV1(),
+			},
+		}
+
+		revisionMf := &appsv1.ControllerRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ntoconfig.WatchNamespace(),
+				Labels:    map[string]string{tunedv1.TunedRevisionTypeLabelKey: tunedv1.TunedRevisionTypeRendered},
+			},
+			Data: runtime.RawExtension{Raw: data},
+		}
+		have, err := c.syncRevision(revisionMf, prefix, selector, false)
+		if err != nil {
+			t.Errorf("failed test case %d: %v", i+1, err)
+			continue
+		}
+		if have != tc.want {
+			t.Errorf("failed test case %d:\n\twant: %s\n\thave: %s", i+1, tc.want, have)
+		}
+
+		revisionList, err := c.clients.Apps.ControllerRevisions(ntoconfig.WatchNamespace()).List(context.TODO(), metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created := len(revisionList.Items) > len(tc.existing); created != tc.created {
+			t.Errorf("failed test case %d:\n\twant created: %v\n\thave: %v", i+1, tc.created, created)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package operator
+
+import (
+	"reflect"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+const (
+	// rolloutRequeueInterval is the period after which a Profile update held back
+	// by a rollout strategy is reconsidered.
+	rolloutRequeueInterval = 15 * time.Second
+)
+
+// rolloutState is the state of a rollout of Profile updates for a single
+// Tuned recommend item.
+type rolloutState struct {
+	selected int // Nodes selected by the recommend item
+	updated  int // Nodes with Profiles matching the computed profile
+	pending  int // updated Profiles not yet applied by the TuneD daemon
+	degraded int // updated Profiles reporting the Degraded condition
+}
+
+// paused returns true if a rollout with the strategy 'strategy' in state 's'
+// is halted due to degraded Profiles.
+func (s rolloutState) paused(strategy *tunedv1.RolloutStrategy) bool {
+	return strategy != nil && ptr.Deref(strategy.PauseOnDegraded, true) && s.degraded > 0 && s.updated < s.selected
+}
+
+// profileUpToDate returns true if Profile 'profile' carries the computed
+// profile 'computed'.
+func profileUpToDate(profile *tunedv1.Profile, computed ComputedProfile) bool {
+	return profile.Spec.Config.TunedProfile == computed.TunedProfileName &&
+		profile.Spec.Config.Debug == computed.Operand.Debug &&
+		profile.Spec.Config.Verbosity == computed.Operand.Verbosity &&
+		reflect.DeepEqual(profile.Spec.Config.TuneDConfig, computed.Operand.TuneDConfig) &&
+		profile.Spec.Config.RollbackOnDegraded == computed.Operand.RollbackOnDegraded &&
+		reflect.DeepEqual(profile.Spec.Config.VerifyInterval, computed.Operand.VerifyInterval) &&
+		reflect.DeepEqual(profile.Spec.Profile, computed.AllProfiles)
+}
+
+// rolloutStateGet calculates the state of the rollout of the computed profile
+// 'computed' recorded in the rendered profile set revision 'revision' across all
+// Nodes selected by the same recommend item.
+func (c *Controller) rolloutStateGet(computed ComputedProfile, revision string) (rolloutState, error) {
+	var state rolloutState
+
+	for nodeName, source := range c.recommendSelected {
+		if source != computed.Source {
+			continue
+		}
+		state.selected++
+
+		profile, err := c.listers.TunedProfiles.Get(nodeName)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return state, err
+		}
+		if updated, ok := c.rolloutUpdated[nodeName]; ok {
+			if profile.Generation < updated.Generation {
+				// The informer cache has not caught up with our own update yet.
+				profile = updated
+			} else {
+				delete(c.rolloutUpdated, nodeName)
+			}
+		}
+
+		// Nodes selected by the same recommend item share the rendered profile set revision.
+		if profile.Annotations[tunedv1.TunedRevisionAnnotationKey] != revision {
+			continue
+		}
+		state.updated++
+		if profile.Status.Revision != revision ||
+			profile.Status.TunedProfile != profile.Spec.Config.TunedProfile {
+			// The TuneD daemon has not picked up the update yet.
+			state.pending++
+			continue
+		}
+		if profileDegraded(profile) || profileRolledBack(profile) {
+			state.degraded++
+			continue
+		}
+		if !profileApplied(profile) {
+			state.pending++
+		}
+	}
+
+	return state, nil
+}
+
+// rolloutScaledValue returns the number of Nodes out of 'selected' Nodes given by
+// the value 'value' of rollout strategy field 'field', but at least 1.
+func rolloutScaledValue(field string, value intstr.IntOrString, selected int) int {
+	n, err := intstr.GetScaledValueFromIntOrPercent(&value, selected, true)
+	if err != nil {
+		klog.Errorf("invalid rollout %s %q: %v", field, value.String(), err)
+		n = 1
+	}
+	if n < 1 {
+		n = 1
+	}
+	return n
+}
+
+// rolloutAllows returns true if the rollout strategy 'strategy' in state 'state'
+// allows updating another Profile when 'wave' Profiles were updated in the current
+// rollout wave.
+func rolloutAllows(strategy *tunedv1.RolloutStrategy, state rolloutState, wave int) bool {
+	if strategy == nil {
+		return true
+	}
+
+	if state.paused(strategy) {
+		return false
+	}
+
+	maxUnavailable := intstr.FromInt32(1)
+	if strategy.BatchSize != nil {
+		if wave >= rolloutScaledValue("batchSize", *strategy.BatchSize, state.selected) {
+			return false
+		}
+		maxUnavailable = *strategy.BatchSize
+	}
+	if strategy.MaxUnavailable != nil {
+		maxUnavailable = *strategy.MaxUnavailable
+	}
+
+	return state.pending+state.degraded < rolloutScaledValue("maxUnavailable", maxUnavailable, state.selected)
+}
+
+// syncRollout records the rollout state for the computed profile 'computed'
+// recorded in the rendered profile set revision 'revision' and returns true
+// if the rollout allows updating another Profile now.
+func (c *Controller) syncRollout(computed ComputedProfile, revision string) (bool, error) {
+	state, err := c.rolloutStateGet(computed, revision)
+	if err != nil {
+		return false, err
+	}
+
+	if c.rollouts[computed.Source] != state {
+		c.rollouts[computed.Source] = state
+		c.enqueueTunedStatusUpdate()
+	}
+	if state.pending == 0 {
+		// All Profiles of the current wave were applied, start a new one.
+		delete(c.rolloutWaves, computed.Source)
+	}
+
+	allowed := rolloutAllows(computed.Rollout, state, c.rolloutWaves[computed.Source])
+	if !allowed {
+		klog.V(2).Infof("syncRollout(): rollout of TuneD profile %s held back (updated=%d/%d, pending=%d, degraded=%d, wave=%d)",
+			computed.TunedProfileName, state.updated, state.selected, state.pending, state.degraded, c.rolloutWaves[computed.Source])
+	}
+
+	return allowed, nil
+}
+
+// setTunedRolloutStatus sets the rollout related fields of Tuned status 'status'
+// for Tuned 'tuned' based on the rollout states 'rollouts'.
+func setTunedRolloutStatus(tuned *tunedv1.Tuned, status *tunedv1.TunedStatus, rollouts map[TunedRecommendSource]rolloutState) {
+	paused := false
+
+	for i, recommend := range tuned.Spec.Recommend {
+		if recommend.Rollout == nil || i >= len(status.Recommend) {
+			continue
+		}
+		state := rollouts[TunedRecommendSource{TunedName: tuned.Name, Index: i}]
+		updated := int32(state.updated)
+		status.Recommend[i].UpdatedNodes = &updated
+		status.Recommend[i].RolloutPaused = state.paused(recommend.Rollout)
+		paused = paused || status.Recommend[i].RolloutPaused
+	}
+
+	pausedCondition := tunedv1.TunedStatusCondition{
+		Type: tunedv1.TunedRolloutPaused,
+	}
+	if paused {
+		pausedCondition.Status = corev1.ConditionTrue
+		pausedCondition.Reason = "ProfileDegraded"
+		pausedCondition.Message = "Rollout of Profile updates halted due to degraded Profiles."
+	} else {
+		pausedCondition.Status = corev1.ConditionFalse
+		pausedCondition.Reason = "AsExpected"
+		pausedCondition.Message = "No rollout of Profile updates is paused."
+	}
+	status.Conditions = setTunedStatusCondition(status.Conditions, &pausedCondition)
+}
+
+// rolloutsPrune removes rollout states of recommend items no longer found
+// in Tuned objects 'tunedList' or without a rollout strategy.
+func (c *Controller) rolloutsPrune(tunedList []*tunedv1.Tuned) {
+	strategies := map[TunedRecommendSource]bool{}
+	for _, tuned := range tunedList {
+		for i, recommend := range tuned.Spec.Recommend {
+			if recommend.Rollout != nil {
+				strategies[TunedRecommendSource{TunedName: tuned.Name, Index: i}] = true
+			}
+		}
+	}
+
+	for source := range c.rollouts {
+		if !strategies[source] {
+			delete(c.rollouts, source)
+		}
+	}
+	for source := range c.rolloutWaves {
+		if !strategies[source] {
+			delete(c.rolloutWaves, source)
+		}
+	}
+}
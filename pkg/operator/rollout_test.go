@@ -0,0 +1,193 @@
+package operator
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoclient "github.com/openshift/cluster-node-tuning-operator/pkg/client"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+	ntolisters "github.com/openshift/cluster-node-tuning-operator/pkg/generated/listers/tuned/v1"
+)
+
+func TestRolloutAllows(t *testing.T) {
+	tests := []struct {
+		strategy *tunedv1.RolloutStrategy
+		state    rolloutState
+		wave     int
+		expected bool
+	}{
+		{
+			// No rollout strategy, update everything at once.
+			strategy: nil,
+			state:    rolloutState{selected: 10, updated: 5, pending: 5},
+			expected: true,
+		},
+		{
+			// Default wave of a single Node, nothing pending.
+			strategy: &tunedv1.RolloutStrategy{},
+			state:    rolloutState{selected: 10, updated: 1},
+			expected: true,
+		},
+		{
+			// Default wave of a single Node, one update pending.
+			strategy: &tunedv1.RolloutStrategy{},
+			state:    rolloutState{selected: 10, updated: 1, pending: 1},
+			expected: false,
+		},
+		{
+			// Absolute wave size.
+			strategy: &tunedv1.RolloutStrategy{MaxUnavailable: ptr.To(intstr.FromInt32(3))},
+			state:    rolloutState{selected: 10, updated: 2, pending: 2},
+			expected: true,
+		},
+		{
+			// Percentage wave size rounds up: 25% of 10 is 3.
+			strategy: &tunedv1.RolloutStrategy{MaxUnavailable: ptr.To(intstr.FromString("25%"))},
+			state:    rolloutState{selected: 10, updated: 3, pending: 3},
+			expected: false,
+		},
+		{
+			// Zero wave size still updates a single Node at a time.
+			strategy: &tunedv1.RolloutStrategy{MaxUnavailable: ptr.To(intstr.FromInt32(0))},
+			state:    rolloutState{selected: 10},
+			expected: true,
+		},
+		{
+			// Degraded Profiles pause the rollout by default.
+			strategy: &tunedv1.RolloutStrategy{MaxUnavailable: ptr.To(intstr.FromInt32(3))},
+			state:    rolloutState{selected: 10, updated: 1, degraded: 1},
+			expected: false,
+		},
+		{
+			// Degraded Profiles without pausing on them count as unavailable.
+			strategy: &tunedv1.RolloutStrategy{PauseOnDegraded: ptr.To(false)},
+			state:    rolloutState{selected: 10, updated: 1, degraded: 1},
+			expected: false,
+		},
+		{
+			// Degraded Profiles without pausing on them within maxUnavailable.
+			strategy: &tunedv1.RolloutStrategy{MaxUnavailable: ptr.To(intstr.FromInt32(2)), PauseOnDegraded: ptr.To(false)},
+			state:    rolloutState{selected: 10, updated: 1, degraded: 1},
+			expected: true,
+		},
+		{
+			// Batch size defaults maxUnavailable.
+			strategy: &tunedv1.RolloutStrategy{BatchSize: ptr.To(intstr.FromInt32(3))},
+			state:    rolloutState{selected: 10, updated: 2, pending: 2},
+			wave:     2,
+			expected: true,
+		},
+		{
+			// Full batch waits for the wave to be applied.
+			strategy: &tunedv1.RolloutStrategy{BatchSize: ptr.To(intstr.FromInt32(3))},
+			state:    rolloutState{selected: 10, updated: 3, pending: 1},
+			wave:     3,
+			expected: false,
+		},
+		{
+			// Batch size limited by maxUnavailable.
+			strategy: &tunedv1.RolloutStrategy{BatchSize: ptr.To(intstr.FromString("50%")), MaxUnavailable: ptr.To(intstr.FromInt32(1))},
+			state:    rolloutState{selected: 10, updated: 1, pending: 1},
+			wave:     1,
+			expected: false,
+		},
+	}
+
+	for i, tc := range tests {
+		allowed := rolloutAllows(tc.strategy, tc.state, tc.wave)
+		if allowed != tc.expected {
+			t.Errorf("failed test case %d:\n\twant: %v\n\thave: %v", i+1, tc.expected, allowed)
+		}
+	}
+}
+
+func TestSetTunedRolloutStatus(t *testing.T) {
+	tuned := &tunedv1.Tuned{}
+	tuned.Name = "custom"
+	tuned.Spec.Recommend = []tunedv1.TunedRecommend{
+		{Profile: ptr.To("a")},
+		{Profile: ptr.To("b"), Rollout: &tunedv1.RolloutStrategy{}},
+	}
+	status := tunedv1.TunedStatus{Recommend: make([]tunedv1.TunedRecommendStatus, 2)}
+	rollouts := map[TunedRecommendSource]rolloutState{
+		{TunedName: "custom", Index: 1}: {selected: 4, updated: 2, degraded: 1},
+	}
+
+	setTunedRolloutStatus(tuned, &status, rollouts)
+
+	if status.Recommend[0].UpdatedNodes != nil {
+		t.Errorf("want no updated Nodes for recommend item without rollout strategy, have: %d", *status.Recommend[0].UpdatedNodes)
+	}
+	if status.Recommend[1].UpdatedNodes == nil || *status.Recommend[1].UpdatedNodes != 2 {
+		t.Errorf("want 2 updated Nodes, have: %v", status.Recommend[1].UpdatedNodes)
+	}
+	if !status.Recommend[1].RolloutPaused {
+		t.Errorf("want paused rollout")
+	}
+	if len(status.Conditions) != 1 || status.Conditions[0].Type != tunedv1.TunedRolloutPaused || status.Conditions[0].Status != "True" {
+		t.Errorf("want RolloutPaused=True condition, have: %v", status.Conditions)
+	}
+}
+
+func TestRolloutStateGet(t *testing.T) {
+	const revision = "rendered-new"
+	source := TunedRecommendSource{TunedName: "custom", Index: 1}
+
+	profile := func(name, annotated, applied string, conditions ...tunedv1.ProfileStatusCondition) *tunedv1.Profile {
+		p := &tunedv1.Profile{}
+		p.Name = name
+		p.Namespace = ntoconfig.WatchNamespace()
+		p.Annotations = map[string]string{tunedv1.TunedRevisionAnnotationKey: annotated}
+		p.Spec.Config.TunedProfile = "openshift-custom"
+		p.Status.TunedProfile = "openshift-custom"
+		p.Status.Revision = applied
+		p.Status.Conditions = conditions
+		return p
+	}
+	applied := tunedv1.ProfileStatusCondition{Type: tunedv1.TunedProfileApplied, Status: corev1.ConditionTrue}
+	degraded := tunedv1.ProfileStatusCondition{Type: tunedv1.TunedDegraded, Status: corev1.ConditionTrue}
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, p := range []*tunedv1.Profile{
+		profile("old", "rendered-old", "rendered-old", applied),
+		profile("pending", revision, "rendered-old", applied),
+		profile("applying", revision, revision),
+		profile("applied", revision, revision, applied),
+		profile("degraded", revision, revision, applied, degraded),
+		profile("other", revision, revision, applied),
+	} {
+		if err := indexer.Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c := &Controller{
+		listers: &ntoclient.Listers{
+			TunedProfiles: ntolisters.NewProfileLister(indexer).Profiles(ntoconfig.WatchNamespace()),
+		},
+		recommendSelected: map[string]TunedRecommendSource{
+			"old":      source,
+			"pending":  source,
+			"applying": source,
+			"applied":  source,
+			"degraded": source,
+			"missing":  source,
+			"other":    {TunedName: "custom", Index: 0},
+		},
+		rolloutUpdated: map[string]*tunedv1.Profile{},
+	}
+
+	have, err := c.rolloutStateGet(ComputedProfile{Source: source}, revision)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := rolloutState{selected: 6, updated: 4, pending: 2, degraded: 1}
+	if have != want {
+		t.Errorf("failed test case:\n\twant: %+v\n\thave: %+v", want, have)
+	}
+}
@@ -0,0 +1,25 @@
+package operator
+
+import (
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+// profileSelection returns the recommend item which selected the TuneD profile
+// of the computed profile 'computed' and the match rules which selected it.
+// Returns nil if no recommend item selected the TuneD profile.
+func profileSelection(computed ComputedProfile) *tunedv1.ProfileSelection {
+	if computed.Source.TunedName == "" {
+		return nil
+	}
+
+	selection := &tunedv1.ProfileSelection{
+		Tuned:          computed.Source.TunedName,
+		RecommendIndex: computed.Source.Index,
+		Priority:       computed.Priority,
+	}
+	if len(computed.MatchPath) > 0 {
+		selection.Match = computed.MatchPath
+	}
+
+	return selection
+}
@@ -0,0 +1,189 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func TestProfileSelection(t *testing.T) {
+	tuneds := []*tunedv1.Tuned{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+			Spec: tunedv1.TunedSpec{
+				Profile: []tunedv1.TunedProfile{
+					{Name: ptr.To("openshift-node"), Data: ptr.To("[main]")},
+				},
+				Recommend: []tunedv1.TunedRecommend{
+					{
+						Profile:  ptr.To("openshift-node"),
+						Priority: ptr.To[uint64](40),
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+			Spec: tunedv1.TunedSpec{
+				Profile: []tunedv1.TunedProfile{
+					{Name: ptr.To("worker-gold"), Data: ptr.To("[main]")},
+					{Name: ptr.To("rt"), Data: ptr.To("[main]")},
+				},
+				Recommend: []tunedv1.TunedRecommend{
+					{
+						Profile:  ptr.To("worker-gold"),
+						Priority: ptr.To[uint64](30),
+						Match: []tunedv1.TunedMatch{
+							{
+								Label: ptr.To("node-role.kubernetes.io/master"),
+							},
+							{
+								Label: ptr.To("node-role.kubernetes.io/worker"),
+								Match: []tunedv1.TunedMatch{
+									{
+										Label: ptr.To("example.com/tier"),
+										Value: ptr.To("gold"),
+									},
+								},
+							},
+						},
+					},
+					{
+						Profile:             ptr.To("rt"),
+						Priority:            ptr.To[uint64](20),
+						MachineConfigLabels: map[string]string{"machineconfiguration.openshift.io/role": "worker-rt"},
+					},
+				},
+			},
+		},
+	}
+	nodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "gold",
+				Labels: map[string]string{"node-role.kubernetes.io/worker": "", "example.com/tier": "gold"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "rt",
+				Labels: map[string]string{"node-role.kubernetes.io/worker-rt": ""},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "other",
+				Labels: map[string]string{"node-role.kubernetes.io/infra": ""},
+			},
+		},
+	}
+	pools := []*mcfgv1.MachineConfigPool{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "worker-rt"},
+			Spec: mcfgv1.MachineConfigPoolSpec{
+				NodeSelector:          &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/worker-rt": ""}},
+				MachineConfigSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"machineconfiguration.openshift.io/role": "worker-rt"}},
+			},
+		},
+	}
+
+	pc, err := NewOfflineProfileCalculator(tuneds, nodes, nil, pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		nodeName string
+		expected *tunedv1.ProfileSelection
+	}{
+		{
+			nodeName: "gold",
+			expected: &tunedv1.ProfileSelection{
+				Tuned:          "custom",
+				RecommendIndex: 0,
+				Priority:       ptr.To[uint64](30),
+				Match:          []string{"node label node-role.kubernetes.io/worker", "node label example.com/tier=gold"},
+			},
+		},
+		{
+			nodeName: "rt",
+			expected: &tunedv1.ProfileSelection{
+				Tuned:          "custom",
+				RecommendIndex: 1,
+				Priority:       ptr.To[uint64](20),
+				Match:          []string{"machineConfigLabels machineconfiguration.openshift.io/role=worker-rt"},
+			},
+		},
+		{
+			nodeName: "other",
+			expected: &tunedv1.ProfileSelection{
+				Tuned:          "default",
+				RecommendIndex: 0,
+				Priority:       ptr.To[uint64](40),
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		computed, err := pc.calculateProfile(tc.nodeName)
+		if err != nil {
+			t.Errorf("failed test case %d: %v", i, err)
+			continue
+		}
+		selection := profileSelection(computed)
+		if !reflect.DeepEqual(selection, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %+v\n\thave: %+v", i, tc.expected, selection)
+		}
+	}
+
+	if selection := profileSelection(ComputedProfile{TunedProfileName: defaultProfile}); selection != nil {
+		t.Errorf("want no selection without a recommend item, have: %+v", selection)
+	}
+}
+
+func TestProfileOperatorStatus(t *testing.T) {
+	conflict := &tunedv1.BootcmdlineConflict{Pool: "worker"}
+	selection := &tunedv1.ProfileSelection{Tuned: "default", RecommendIndex: 1}
+
+	tests := []struct {
+		computed ComputedProfile
+		expected tunedv1.ProfileStatus
+	}{
+		{
+			// The kernel arguments conflict is kept while the Node contributes to the pool's MachineConfig.
+			computed: ComputedProfile{MCLabels: map[string]string{"machineconfiguration.openshift.io/role": "worker"}},
+			expected: tunedv1.ProfileStatus{
+				TunedProfile:         "openshift-node",
+				BootcmdlineConflict:  conflict,
+				MissingNodeVariables: []string{"${node.label:a}"},
+				SelectedBy:           selection,
+			},
+		},
+		{
+			computed: ComputedProfile{},
+			expected: tunedv1.ProfileStatus{
+				TunedProfile:         "openshift-node",
+				MissingNodeVariables: []string{"${node.label:a}"},
+				SelectedBy:           selection,
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		status := tunedv1.ProfileStatus{
+			TunedProfile:        "openshift-node",
+			BootcmdlineConflict: conflict,
+		}
+		profileOperatorStatus(selection, tc.computed, []string{"${node.label:a}"})(&status)
+		if !reflect.DeepEqual(status, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %+v\n\thave: %+v", i, tc.expected, status)
+		}
+	}
+}
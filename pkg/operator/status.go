@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	configv1 "github.com/openshift/api/config/v1"
 	operatorv1 "github.com/openshift/api/operator/v1"
@@ -24,6 +25,14 @@ import (
 
 const (
 	errGenerationMismatch = "generation mismatch"
+
+	// operatorRebootRequired is the ClusterOperator condition reporting nodes whose
+	// running kernel command-line does not match the kernel arguments calculated by TuneD.
+	operatorRebootRequired configv1.ClusterStatusConditionType = "RebootRequired"
+
+	// operatorPriorityConflict is the ClusterOperator condition reporting nodes matched
+	// by Tuned recommend items with the same priority, but different TuneD profiles.
+	operatorPriorityConflict configv1.ClusterStatusConditionType = "PriorityConflict"
 )
 
 // syncOperatorStatus computes the operator's current status and therefrom
@@ -128,14 +137,61 @@ func profileDegraded(profile *tunedv1.Profile) bool {
 	return false
 }
 
+// profileRolledBack returns true if Profile 'profile' reports the TuneD daemon
+// reverted to the last TuneD profile which applied cleanly.
+func profileRolledBack(profile *tunedv1.Profile) bool {
+	if profile == nil {
+		return false
+	}
+
+	for _, sc := range profile.Status.Conditions {
+		if sc.Type == tunedv1.TunedRolledBack && sc.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// profileRebootRequired returns true if Profile 'profile' reports the running kernel
+// command-line does not match the kernel arguments calculated by TuneD.
+func profileRebootRequired(profile *tunedv1.Profile) bool {
+	if profile == nil {
+		return false
+	}
+
+	for _, sc := range profile.Status.Conditions {
+		if sc.Type == tunedv1.TunedRebootRequired && sc.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+
+	return false
+}
+
+// numProfilesRebootRequired returns the number of Profiles in the slice
+// 'profileList' whose nodes need a reboot to use the calculated kernel arguments.
+func numProfilesRebootRequired(profileList []*tunedv1.Profile) int {
+	numRebootRequired := 0
+	for _, profile := range profileList {
+		if profileRebootRequired(profile) {
+			numRebootRequired++
+		}
+	}
+
+	return numRebootRequired
+}
+
 // numProfilesProgressingDegraded returns two ints which count
 // the number of Profiles in the slice 'profileList' which are
 // waiting to be applied and in a degraded state, respectively.
+// Profiles rolled back to the last good TuneD profile failed to
+// apply their TuneD profile and count as degraded.
 func numProfilesProgressingDegraded(profileList []*tunedv1.Profile) (int, int) {
 	numDegraded := 0
 	numProgressing := 0
 	for _, profile := range profileList {
-		if profileDegraded(profile) {
+		if profileDegraded(profile) || profileRolledBack(profile) {
 			numDegraded++
 			continue
 		}
@@ -162,6 +218,27 @@ func (c *Controller) numProfilesWithBootcmdlineConflict(profileList []*tunedv1.P
 	return numConflict
 }
 
+// profileMetricsStates returns the per-node states of Profiles 'profileList'
+// exposed in metrics.  'bootcmdlineConflict' are the Profiles tracked as having
+// kernel command-line conflict (indexed by Profile name).
+func profileMetricsStates(profileList []*tunedv1.Profile, bootcmdlineConflict map[string]bool) []metrics.ProfileState {
+	states := make([]metrics.ProfileState, 0, len(profileList))
+	for _, profile := range profileList {
+		state := metrics.ProfileState{
+			Node:                profile.Name,
+			TunedProfile:        profile.Status.TunedProfile,
+			Applied:             profileApplied(profile),
+			Degraded:            profileDegraded(profile) || profileRolledBack(profile),
+			BootcmdlineConflict: bootcmdlineConflict[profile.Name],
+		}
+		// Keep in sync with numProfilesProgressingDegraded().
+		state.Progressing = !state.Degraded && !state.Applied
+		states = append(states, state)
+	}
+
+	return states
+}
+
 // computeStatus computes the operator's current status.
 func (c *Controller) computeStatus(tuned *tunedv1.Tuned, conditions []configv1.ClusterOperatorStatusCondition) ([]configv1.ClusterOperatorStatusCondition, string, error) {
 	const (
@@ -182,6 +259,18 @@ func (c *Controller) computeStatus(tuned *tunedv1.Tuned, conditions []configv1.C
 	degradedCondition := configv1.ClusterOperatorStatusCondition{
 		Type: configv1.OperatorDegraded,
 	}
+	rebootRequiredCondition := configv1.ClusterOperatorStatusCondition{
+		Type:    operatorRebootRequired,
+		Status:  configv1.ConditionFalse,
+		Reason:  "AsExpected",
+		Message: "No node needs a reboot to use the kernel command-line calculated by TuneD",
+	}
+	priorityConflictCondition := configv1.ClusterOperatorStatusCondition{
+		Type:    operatorPriorityConflict,
+		Status:  configv1.ConditionFalse,
+		Reason:  "AsExpected",
+		Message: "No node is matched by Tuned recommend items with the same priority and different TuneD profiles",
+	}
 
 	copyAvailableCondition := func() {
 		progressingCondition.Status = availableCondition.Status
@@ -296,6 +385,9 @@ func (c *Controller) computeStatus(tuned *tunedv1.Tuned, conditions []configv1.C
 		}
 
 		numProgressingProfiles, numDegradedProfiles := numProfilesProgressingDegraded(profileList)
+		if err == nil {
+			metrics.ProfileStates(profileMetricsStates(profileList, c.bootcmdlineConflict))
+		}
 
 		if numProgressingProfiles > 0 {
 			progressingCondition.Status = configv1.ConditionTrue
@@ -309,12 +401,30 @@ func (c *Controller) computeStatus(tuned *tunedv1.Tuned, conditions []configv1.C
 			availableCondition.Message = fmt.Sprintf("%v/%v Profiles failed to be applied", numDegradedProfiles, len(profileList))
 		}
 
+		numRebootRequired := numProfilesRebootRequired(profileList)
+		if numRebootRequired > 0 {
+			klog.Infof("%v/%v Profiles require a node reboot", numRebootRequired, len(profileList))
+			rebootRequiredCondition.Status = configv1.ConditionTrue
+			rebootRequiredCondition.Reason = "KernelArgumentsMismatch"
+			rebootRequiredCondition.Message = fmt.Sprintf("%v/%v Profiles require a node reboot to use the kernel command-line calculated by TuneD", numRebootRequired, len(profileList))
+		}
+
+		if nodes := priorityConflictNodes(c.priorityConflicts); len(nodes) > 0 {
+			priorityConflictCondition.Status = configv1.ConditionTrue
+			priorityConflictCondition.Reason = "SamePriority"
+			priorityConflictCondition.Message = fmt.Sprintf("%v Node(s) matched by Tuned recommend items with the same priority and different TuneD profiles: %s; see the PriorityConflict condition of the Tuned objects",
+				len(nodes), priorityConflictNodesString(nodes))
+		}
+
 		numConflict := c.numProfilesWithBootcmdlineConflict(profileList)
 		if numConflict > 0 {
 			klog.Infof("%v/%v Profiles with bootcmdline conflict", numConflict, len(profileList))
 			degradedCondition.Status = configv1.ConditionTrue
 			degradedCondition.Reason = "ProfileConflict"
 			degradedCondition.Message = fmt.Sprintf("%v/%v Profiles with bootcmdline conflict", numConflict, len(profileList))
+			if pools := bootcmdlineConflictPools(c.poolBootcmdlineConflict); len(pools) > 0 {
+				degradedCondition.Message += fmt.Sprintf(" in pool(s) %s; see status.bootcmdlineConflict of the Profiles", strings.Join(pools, ", "))
+			}
 		}
 
 		// If the operator is not available for an extensive period of time, set the Degraded operator status.
@@ -344,12 +454,22 @@ func (c *Controller) computeStatus(tuned *tunedv1.Tuned, conditions []configv1.C
 		degradedCondition.Reason = availableCondition.Reason
 		degradedCondition.Message = availableCondition.Message
 
+		rebootRequiredCondition.Status = configv1.ConditionFalse
+		rebootRequiredCondition.Reason = availableCondition.Reason
+		rebootRequiredCondition.Message = availableCondition.Message
+
+		priorityConflictCondition.Status = configv1.ConditionFalse
+		priorityConflictCondition.Reason = availableCondition.Reason
+		priorityConflictCondition.Message = availableCondition.Message
+
 	default:
 	}
 
 	conditions = clusteroperator.SetStatusCondition(conditions, &availableCondition)
 	conditions = clusteroperator.SetStatusCondition(conditions, &progressingCondition)
 	conditions = clusteroperator.SetStatusCondition(conditions, &degradedCondition)
+	conditions = clusteroperator.SetStatusCondition(conditions, &rebootRequiredCondition)
+	conditions = clusteroperator.SetStatusCondition(conditions, &priorityConflictCondition)
 
 	klog.V(3).Infof("operator status conditions: %v", conditions)
 
@@ -0,0 +1,96 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
+)
+
+func newTestProfile(name, specProfile, statusProfile string, conditions ...tunedv1.ProfileStatusCondition) *tunedv1.Profile {
+	return &tunedv1.Profile{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: tunedv1.ProfileSpec{
+			Config: tunedv1.ProfileConfig{TunedProfile: specProfile},
+		},
+		Status: tunedv1.ProfileStatus{
+			TunedProfile: statusProfile,
+			Conditions:   conditions,
+		},
+	}
+}
+
+func TestProfileMetricsStates(t *testing.T) {
+	applied := tunedv1.ProfileStatusCondition{Type: tunedv1.TunedProfileApplied, Status: corev1.ConditionTrue}
+	notApplied := tunedv1.ProfileStatusCondition{Type: tunedv1.TunedProfileApplied, Status: corev1.ConditionFalse}
+	degraded := tunedv1.ProfileStatusCondition{Type: tunedv1.TunedDegraded, Status: corev1.ConditionTrue}
+	rolledBack := tunedv1.ProfileStatusCondition{Type: tunedv1.TunedRolledBack, Status: corev1.ConditionTrue}
+
+	profileList := []*tunedv1.Profile{
+		newTestProfile("node1", "openshift-node", "openshift-node", applied),
+		newTestProfile("node2", "openshift-node", "openshift-node", notApplied, degraded),
+		newTestProfile("node3", "openshift-node-custom", "openshift-node", applied),
+		newTestProfile("node4", "openshift-node-custom", "openshift-node", applied, rolledBack),
+	}
+	bootcmdlineConflict := map[string]bool{"node1": true}
+
+	expected := []metrics.ProfileState{
+		{Node: "node1", TunedProfile: "openshift-node", Applied: true, BootcmdlineConflict: true},
+		{Node: "node2", TunedProfile: "openshift-node", Degraded: true},
+		{Node: "node3", TunedProfile: "openshift-node", Progressing: true},
+		{Node: "node4", TunedProfile: "openshift-node", Degraded: true},
+	}
+
+	have := profileMetricsStates(profileList, bootcmdlineConflict)
+	if !reflect.DeepEqual(have, expected) {
+		t.Errorf("failed test case:\n\twant: %+v\n\thave: %+v", expected, have)
+	}
+
+	numProgressing, numDegraded := numProfilesProgressingDegraded(profileList)
+	if numProgressing != 1 || numDegraded != 2 {
+		t.Errorf("failed test case:\n\twant: progressing=1 degraded=2\n\thave: progressing=%d degraded=%d", numProgressing, numDegraded)
+	}
+}
+
+func TestNumProfilesRebootRequired(t *testing.T) {
+	rebootRequired := tunedv1.ProfileStatusCondition{Type: tunedv1.TunedRebootRequired, Status: corev1.ConditionTrue}
+	noRebootRequired := tunedv1.ProfileStatusCondition{Type: tunedv1.TunedRebootRequired, Status: corev1.ConditionFalse}
+
+	tests := []struct {
+		profileList []*tunedv1.Profile
+		expected    int
+	}{
+		{
+			profileList: nil,
+			expected:    0,
+		},
+		{
+			profileList: []*tunedv1.Profile{
+				newTestProfile("node1", "openshift-node", "openshift-node"),
+				newTestProfile("node2", "openshift-node", "openshift-node", noRebootRequired),
+			},
+			expected: 0,
+		},
+		{
+			profileList: []*tunedv1.Profile{
+				newTestProfile("node1", "openshift-node", "openshift-node", rebootRequired),
+				newTestProfile("node2", "openshift-node", "openshift-node", noRebootRequired),
+				newTestProfile("node3", "openshift-node", "openshift-node", rebootRequired),
+			},
+			expected: 2,
+		},
+	}
+
+	for i, tc := range tests {
+		have := numProfilesRebootRequired(tc.profileList)
+		if have != tc.expected {
+			t.Errorf("failed test case %d:\n\twant: %d\n\thave: %d", i, tc.expected, have)
+		}
+	}
+}
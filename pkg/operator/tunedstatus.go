@@ -0,0 +1,391 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/ini.v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kcorelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/klog/v2"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
+)
+
+// syncTunedStatuses computes the status of all Tuned objects and updates
+// the status of those whose status changed.
+func (c *Controller) syncTunedStatuses() error {
+	var (
+		lastErr error
+		unused  []metrics.RecommendUnused
+	)
+
+	tunedList, err := c.listers.TunedResources.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list Tuned: %v", err)
+	}
+
+	c.rolloutsPrune(tunedList)
+	shadowedBy := recommendShadowedBy(tunedList)
+	profilesSynced, err := c.profilesSyncedCheck()
+	if err != nil {
+		return err
+	}
+
+	for _, tuned := range tunedList {
+		status := computeTunedStatus(tuned, tunedList, c.recommendSelected, c.listers.TunedConfigMaps)
+		setTunedRolloutStatus(tuned, &status, c.rollouts)
+		setTunedPriorityConflictStatus(tuned, &status, c.priorityConflicts)
+		unused = append(unused, setTunedRecommendUnused(tuned, &status, shadowedBy, profilesSynced)...)
+		status.BootcmdlineConflicts = tunedBootcmdlineConflicts(tuned, c.poolBootcmdlineConflict)
+		if util.HasDryRunAnnotation(tuned.Annotations) && !ntoconfig.InHyperShift() {
+			status.Preview, err = c.tunedPreview(tuned, tunedList)
+			if err != nil {
+				lastErr = fmt.Errorf("failed to calculate preview of Tuned %s: %v", tuned.Name, err)
+				continue
+			}
+		}
+		if reflect.DeepEqual(tuned.Status, status) {
+			klog.V(2).Infof("syncTunedStatuses(): no need to update status of Tuned %s", tuned.Name)
+			continue
+		}
+		tuned = tuned.DeepCopy() // never update the objects from cache
+		tuned.Status = status
+
+		klog.V(2).Infof("syncTunedStatuses(): updating status of Tuned %s", tuned.Name)
+		_, err = c.clients.Tuned.TunedV1().Tuneds(ntoconfig.WatchNamespace()).UpdateStatus(context.TODO(), tuned, metav1.UpdateOptions{})
+		if err != nil {
+			lastErr = fmt.Errorf("failed to update status of Tuned %s: %v", tuned.Name, err)
+		}
+	}
+	metrics.RecommendsUnused(unused)
+
+	return lastErr
+}
+
+// profilesSyncedCheck returns true once every Profile was synced at least once since
+// the operator started.  Until then, the recommend items selected for the individual
+// Nodes are incomplete.
+func (c *Controller) profilesSyncedCheck() (bool, error) {
+	if c.profilesSynced {
+		return true, nil
+	}
+
+	profileList, err := c.listers.TunedProfiles.List(labels.Everything())
+	if err != nil {
+		return false, fmt.Errorf("failed to list Profiles: %v", err)
+	}
+	for _, profile := range profileList {
+		if _, ok := c.recommendSelected[profile.Name]; !ok {
+			return false, nil
+		}
+	}
+	c.profilesSynced = true
+
+	return true, nil
+}
+
+// tunedPreview calculates the effects Tuned 'tuned' in the dry-run mode would have
+// on the Profiles if it was not in the dry-run mode.  'tunedList' are all existing
+// Tuned objects.
+func (c *Controller) tunedPreview(tuned *tunedv1.Tuned, tunedList []*tunedv1.Tuned) (*tunedv1.TunedPreview, error) {
+	preview := &tunedv1.TunedPreview{}
+	pools := map[string]bool{}
+
+	candidate := tuned.DeepCopy()
+	delete(candidate.Annotations, tunedv1.TunedDryRun)
+
+	profileList, err := c.listers.TunedProfiles.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list Profiles: %v", err)
+	}
+
+	for _, profile := range profileList {
+		nodeName := profile.Name
+		if c.pc.state.nodeLabels[nodeName] == nil {
+			// Node labels are not cached (yet), the Profile is not synced either.
+			continue
+		}
+
+		// Always use fresh slices, calculateProfileFromTuneds() reorders them.
+		active := tunedsActive(tunedList)
+		current, err := c.pc.calculateProfileFromTuneds(nodeName, active)
+		if err != nil {
+			return nil, err
+		}
+		previewed, err := c.pc.calculateProfileFromTuneds(nodeName, append(tunedsActive(tunedList), candidate))
+		if err != nil {
+			return nil, err
+		}
+
+		// Both MachineConfig creation and removal cause the Node to reboot.
+		machineConfig := !reflect.DeepEqual(current.MCLabels, previewed.MCLabels) ||
+			(previewed.MCLabels != nil && current.TunedProfileName != previewed.TunedProfileName)
+		if current.TunedProfileName == previewed.TunedProfileName && !machineConfig {
+			continue
+		}
+
+		preview.Nodes = append(preview.Nodes, tunedv1.TunedPreviewNode{
+			Name:           nodeName,
+			CurrentProfile: current.TunedProfileName,
+			Profile:        previewed.TunedProfileName,
+			MachineConfig:  machineConfig,
+		})
+
+		if !machineConfig {
+			continue
+		}
+		node, err := c.listers.Nodes.Get(nodeName)
+		if err != nil {
+			return nil, err
+		}
+		nodePools, err := c.pc.getPoolsForNode(node)
+		if err != nil {
+			return nil, err
+		}
+		for _, pool := range nodePools {
+			pools[pool.Name] = true
+		}
+	}
+
+	sort.Slice(preview.Nodes, func(i, j int) bool {
+		return preview.Nodes[i].Name < preview.Nodes[j].Name
+	})
+	for pool := range pools {
+		preview.MachineConfigPools = append(preview.MachineConfigPools, pool)
+	}
+	sort.Strings(preview.MachineConfigPools)
+
+	return preview, nil
+}
+
+// computeTunedStatus returns the status of Tuned 'tuned' based on all existing
+// Tuned objects 'tunedList' and the recommend items 'selected' for the individual
+// Nodes (indexed by Node name).  TuneD profile data sourced from ConfigMaps is
+// resolved using the ConfigMap lister 'configMaps'.  The conditions of 'tuned' are
+// kept unchanged, including their LastTransitionTime, unless their content changed.
+func computeTunedStatus(tuned *tunedv1.Tuned, tunedList []*tunedv1.Tuned, selected map[string]TunedRecommendSource, configMaps kcorelisters.ConfigMapNamespaceLister) tunedv1.TunedStatus {
+	status := tunedv1.TunedStatus{
+		Conditions: tuned.Status.Conditions,
+	}
+
+	validCondition := tunedv1.TunedStatusCondition{
+		Type: tunedv1.TunedValid,
+	}
+	if invalid := tunedInvalidProfiles(tuned, configMaps); len(invalid) > 0 {
+		validCondition.Status = corev1.ConditionFalse
+		validCondition.Reason = "InvalidProfile"
+		validCondition.Message = "Invalid TuneD profile(s): " + strings.Join(invalid, "; ")
+	} else if invalid := tunedInvalidRecommend(tuned); len(invalid) > 0 {
+		validCondition.Status = corev1.ConditionFalse
+		validCondition.Reason = "InvalidRecommend"
+		validCondition.Message = "Invalid recommend item(s): " + strings.Join(invalid, "; ")
+	} else {
+		validCondition.Status = corev1.ConditionTrue
+		validCondition.Reason = "AsExpected"
+		validCondition.Message = "All TuneD profiles are valid."
+	}
+
+	conflictingCondition := tunedv1.TunedStatusCondition{
+		Type: tunedv1.TunedConflicting,
+	}
+	if conflicts := tunedConflictingProfiles(tuned, tunedList, configMaps); len(conflicts) > 0 {
+		conflictingCondition.Status = corev1.ConditionTrue
+		conflictingCondition.Reason = "ProfileConflict"
+		conflictingCondition.Message = "TuneD profile(s) with different contents defined in other Tuned objects: " + strings.Join(conflicts, ", ")
+	} else {
+		conflictingCondition.Status = corev1.ConditionFalse
+		conflictingCondition.Reason = "AsExpected"
+		conflictingCondition.Message = "No TuneD profile conflicts with other Tuned objects."
+	}
+
+	matchedNodes := make([]int32, len(tuned.Spec.Recommend))
+	for _, source := range selected {
+		if source.TunedName == tuned.Name && source.Index >= 0 && source.Index < len(matchedNodes) {
+			matchedNodes[source.Index]++
+		}
+	}
+
+	var matchedNodesTotal int32
+	for i, recommend := range tuned.Spec.Recommend {
+		recommendStatus := tunedv1.TunedRecommendStatus{
+			MatchedNodes: matchedNodes[i],
+		}
+		if recommend.Profile != nil {
+			recommendStatus.Profile = *recommend.Profile
+		}
+		if recommend.Priority != nil {
+			priority := *recommend.Priority
+			recommendStatus.Priority = &priority
+		}
+		status.Recommend = append(status.Recommend, recommendStatus)
+		matchedNodesTotal += matchedNodes[i]
+	}
+
+	inUseCondition := tunedv1.TunedStatusCondition{
+		Type: tunedv1.TunedInUse,
+	}
+	if matchedNodesTotal > 0 {
+		inUseCondition.Status = corev1.ConditionTrue
+		inUseCondition.Reason = "AsExpected"
+		inUseCondition.Message = "At least one recommend item selected a TuneD profile for a Node."
+	} else {
+		inUseCondition.Status = corev1.ConditionFalse
+		inUseCondition.Reason = "NoMatch"
+		inUseCondition.Message = "No recommend item selected a TuneD profile for any Node."
+	}
+
+	status.Conditions = setTunedStatusCondition(status.Conditions, &validCondition)
+	status.Conditions = setTunedStatusCondition(status.Conditions, &conflictingCondition)
+	status.Conditions = setTunedStatusCondition(status.Conditions, &inUseCondition)
+
+	return status
+}
+
+// tunedInvalidProfiles returns a list of human-readable reasons why the TuneD
+// profiles of Tuned 'tuned' are invalid.  TuneD profile data sourced from ConfigMaps
+// is resolved using the ConfigMap lister 'configMaps'.  An empty list is returned
+// for Tuned objects with valid TuneD profiles.
+func tunedInvalidProfiles(tuned *tunedv1.Tuned, configMaps kcorelisters.ConfigMapNamespaceLister) []string {
+	var invalid []string
+
+	for i, profile := range tuned.Spec.Profile {
+		if profile.Name == nil || len(*profile.Name) == 0 {
+			invalid = append(invalid, fmt.Sprintf("profile[%d] has no name", i))
+			continue
+		}
+		if profile.Data != nil && profile.DataFrom != nil {
+			invalid = append(invalid, fmt.Sprintf("profile %s has both data and dataFrom", *profile.Name))
+			continue
+		}
+		if profile.DataFrom != nil {
+			data, err := tunedProfileDataFrom(profile.DataFrom, configMaps)
+			if err != nil {
+				invalid = append(invalid, fmt.Sprintf("profile %s has invalid dataFrom: %v", *profile.Name, err))
+				continue
+			}
+			if _, err := ini.Load([]byte(data)); err != nil {
+				invalid = append(invalid, fmt.Sprintf("profile %s has invalid data in ConfigMap %s: %v", *profile.Name, profile.DataFrom.ConfigMap, err))
+			}
+			continue
+		}
+		if profile.Data == nil {
+			invalid = append(invalid, fmt.Sprintf("profile %s has no data", *profile.Name))
+			continue
+		}
+		if _, err := ini.Load([]byte(*profile.Data)); err != nil {
+			invalid = append(invalid, fmt.Sprintf("profile %s has invalid data: %v", *profile.Name, err))
+		}
+	}
+
+	return invalid
+}
+
+// tunedInvalidRecommend returns a list of human-readable reasons why the recommend
+// items of Tuned 'tuned' are invalid.  An empty list is returned for Tuned objects
+// with valid recommend items.
+func tunedInvalidRecommend(tuned *tunedv1.Tuned) []string {
+	var invalid []string
+
+	for i, recommend := range tuned.Spec.Recommend {
+		if recommend.NodeSelector == nil {
+			continue
+		}
+		if _, err := metav1.LabelSelectorAsSelector(recommend.NodeSelector); err != nil {
+			invalid = append(invalid, fmt.Sprintf("recommend[%d] has invalid nodeSelector: %v", i, err))
+		}
+	}
+
+	return invalid
+}
+
+// tunedConflictingProfiles returns a sorted list of TuneD profile names defined
+// by Tuned 'tuned' which are also defined with a different content by other
+// Tuned objects from 'tunedList'.  TuneD profile data sourced from ConfigMaps is
+// resolved using the ConfigMap lister 'configMaps'.
+func tunedConflictingProfiles(tuned *tunedv1.Tuned, tunedList []*tunedv1.Tuned, configMaps kcorelisters.ConfigMapNamespaceLister) []string {
+	conflicts := map[string]bool{}
+
+	for _, profile := range tuned.Spec.Profile {
+		if profile.Name == nil {
+			continue
+		}
+		data, err := tunedProfileData(profile, configMaps)
+		if err != nil {
+			// Reported by the Valid condition.
+			continue
+		}
+		for _, other := range tunedList {
+			if other.Name == tuned.Name || !tunedsShareProfiles(tuned, other) {
+				continue
+			}
+			for _, otherProfile := range other.Spec.Profile {
+				if otherProfile.Name == nil || *otherProfile.Name != *profile.Name {
+					continue
+				}
+				otherData, err := tunedProfileData(otherProfile, configMaps)
+				if err != nil {
+					continue
+				}
+				if otherData != data {
+					conflicts[*profile.Name] = true
+				}
+			}
+		}
+	}
+
+	names := make([]string, 0, len(conflicts))
+	for name := range conflicts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// tunedsShareProfiles returns true if the TuneD profiles of Tuned objects 'a' and 'b'
+// can be rendered together for a Node.  In HyperShift, this is only the case for
+// Tuned objects referenced by the same NodePool and the default Tuned.
+func tunedsShareProfiles(a, b *tunedv1.Tuned) bool {
+	aNodePool := a.Labels[hypershiftNodePoolNameLabel]
+	bNodePool := b.Labels[hypershiftNodePoolNameLabel]
+
+	return aNodePool == "" || bNodePool == "" || aNodePool == bNodePool
+}
+
+// setTunedStatusCondition returns the result of setting the specified condition in
+// the given slice of conditions.
+func setTunedStatusCondition(oldConditions []tunedv1.TunedStatusCondition, condition *tunedv1.TunedStatusCondition) []tunedv1.TunedStatusCondition {
+	condition.LastTransitionTime = metav1.Now()
+
+	newConditions := []tunedv1.TunedStatusCondition{}
+
+	found := false
+	for _, c := range oldConditions {
+		if condition.Type == c.Type {
+			if condition.Status == c.Status &&
+				condition.Reason == c.Reason &&
+				condition.Message == c.Message {
+				return oldConditions
+			}
+
+			found = true
+			newConditions = append(newConditions, *condition)
+		} else {
+			newConditions = append(newConditions, c)
+		}
+	}
+	if !found {
+		newConditions = append(newConditions, *condition)
+	}
+
+	return newConditions
+}
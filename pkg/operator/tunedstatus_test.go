@@ -0,0 +1,206 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kcorelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func newTestTuned(name string, profiles []tunedv1.TunedProfile, recommend []tunedv1.TunedRecommend) *tunedv1.Tuned {
+	return &tunedv1.Tuned{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "openshift-cluster-node-tuning-operator",
+		},
+		Spec: tunedv1.TunedSpec{
+			Profile:   profiles,
+			Recommend: recommend,
+		},
+	}
+}
+
+func tunedConditionStatus(conditions []tunedv1.TunedStatusCondition, conditionType tunedv1.TunedConditionType) corev1.ConditionStatus {
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
+
+func TestComputeTunedStatus(t *testing.T) {
+	const namespace = "openshift-cluster-node-tuning-operator"
+
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	err := indexer.Add(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "profiles", Namespace: namespace},
+		Data: map[string]string{
+			"a":       "[main]\nsummary=a other",
+			"invalid": "[main",
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configMaps := kcorelisters.NewConfigMapLister(indexer).ConfigMaps(namespace)
+
+	profileA := tunedv1.TunedProfile{Name: ptr.To("a"), Data: ptr.To("[main]\nsummary=a")}
+	profileAOther := tunedv1.TunedProfile{Name: ptr.To("a"), Data: ptr.To("[main]\nsummary=a other")}
+	profileB := tunedv1.TunedProfile{Name: ptr.To("b"), Data: ptr.To("[main]\nsummary=b")}
+	profileInvalid := tunedv1.TunedProfile{Name: ptr.To("invalid"), Data: ptr.To("[main")}
+	profileNoData := tunedv1.TunedProfile{Name: ptr.To("nodata")}
+	profileADataFrom := tunedv1.TunedProfile{Name: ptr.To("a"), DataFrom: &tunedv1.TunedProfileDataSource{ConfigMap: "profiles", Key: "a"}}
+	profileInvalidDataFrom := tunedv1.TunedProfile{Name: ptr.To("invalid"), DataFrom: &tunedv1.TunedProfileDataSource{ConfigMap: "profiles", Key: "invalid"}}
+	profileMissingKey := tunedv1.TunedProfile{Name: ptr.To("missing-key"), DataFrom: &tunedv1.TunedProfileDataSource{ConfigMap: "profiles", Key: "missing"}}
+	profileMissingConfigMap := tunedv1.TunedProfile{Name: ptr.To("missing-cm"), DataFrom: &tunedv1.TunedProfileDataSource{ConfigMap: "missing", Key: "a"}}
+
+	recommendA := tunedv1.TunedRecommend{Profile: ptr.To("a"), Priority: ptr.To(uint64(10))}
+	recommendB := tunedv1.TunedRecommend{Profile: ptr.To("b"), Priority: ptr.To(uint64(20))}
+
+	tests := []struct {
+		tuned               *tunedv1.Tuned
+		tunedList           []*tunedv1.Tuned
+		selected            map[string]TunedRecommendSource
+		expectedValid       corev1.ConditionStatus
+		expectedConflicting corev1.ConditionStatus
+		expectedInUse       corev1.ConditionStatus
+		expectedRecommend   []tunedv1.TunedRecommendStatus
+	}{
+		{
+			// Valid Tuned, both recommend items selected.
+			tuned: newTestTuned("t1", []tunedv1.TunedProfile{profileA, profileB}, []tunedv1.TunedRecommend{recommendA, recommendB}),
+			selected: map[string]TunedRecommendSource{
+				"node1": {TunedName: "t1", Index: 0},
+				"node2": {TunedName: "t1", Index: 0},
+				"node3": {TunedName: "t1", Index: 1},
+				"node4": {TunedName: "default", Index: 0},
+			},
+			expectedValid:       corev1.ConditionTrue,
+			expectedConflicting: corev1.ConditionFalse,
+			expectedInUse:       corev1.ConditionTrue,
+			expectedRecommend: []tunedv1.TunedRecommendStatus{
+				{Profile: "a", Priority: ptr.To(uint64(10)), MatchedNodes: 2},
+				{Profile: "b", Priority: ptr.To(uint64(20)), MatchedNodes: 1},
+			},
+		},
+		{
+			// Invalid INI data and missing data, nothing selected.
+			tuned:               newTestTuned("t1", []tunedv1.TunedProfile{profileInvalid, profileNoData}, []tunedv1.TunedRecommend{recommendA}),
+			selected:            map[string]TunedRecommendSource{"node1": {TunedName: "default", Index: 0}},
+			expectedValid:       corev1.ConditionFalse,
+			expectedConflicting: corev1.ConditionFalse,
+			expectedInUse:       corev1.ConditionFalse,
+			expectedRecommend: []tunedv1.TunedRecommendStatus{
+				{Profile: "a", Priority: ptr.To(uint64(10)), MatchedNodes: 0},
+			},
+		},
+		{
+			// Invalid nodeSelector.
+			tuned: newTestTuned("t1", []tunedv1.TunedProfile{profileA}, []tunedv1.TunedRecommend{
+				{
+					Profile:  ptr.To("a"),
+					Priority: ptr.To(uint64(10)),
+					NodeSelector: &metav1.LabelSelector{
+						MatchExpressions: []metav1.LabelSelectorRequirement{
+							{Key: "node-role.kubernetes.io/worker", Operator: "Unknown"},
+						},
+					},
+				},
+			}),
+			expectedValid:       corev1.ConditionFalse,
+			expectedConflicting: corev1.ConditionFalse,
+			expectedInUse:       corev1.ConditionFalse,
+			expectedRecommend: []tunedv1.TunedRecommendStatus{
+				{Profile: "a", Priority: ptr.To(uint64(10)), MatchedNodes: 0},
+			},
+		},
+		{
+			// Profile "a" defined with different contents in another Tuned.
+			tuned: newTestTuned("t1", []tunedv1.TunedProfile{profileA}, nil),
+			tunedList: []*tunedv1.Tuned{
+				newTestTuned("t2", []tunedv1.TunedProfile{profileAOther}, nil),
+			},
+			expectedValid:       corev1.ConditionTrue,
+			expectedConflicting: corev1.ConditionTrue,
+			expectedInUse:       corev1.ConditionFalse,
+		},
+		{
+			// Profile "a" defined with the same contents in another Tuned.
+			tuned: newTestTuned("t1", []tunedv1.TunedProfile{profileA}, nil),
+			tunedList: []*tunedv1.Tuned{
+				newTestTuned("t2", []tunedv1.TunedProfile{profileA}, nil),
+			},
+			expectedValid:       corev1.ConditionTrue,
+			expectedConflicting: corev1.ConditionFalse,
+			expectedInUse:       corev1.ConditionFalse,
+		},
+		{
+			// Profile "a" sourced from a ConfigMap with different contents in another Tuned.
+			tuned: newTestTuned("t1", []tunedv1.TunedProfile{profileA}, nil),
+			tunedList: []*tunedv1.Tuned{
+				newTestTuned("t2", []tunedv1.TunedProfile{profileADataFrom}, nil),
+			},
+			expectedValid:       corev1.ConditionTrue,
+			expectedConflicting: corev1.ConditionTrue,
+			expectedInUse:       corev1.ConditionFalse,
+		},
+		{
+			// Invalid INI data sourced from a ConfigMap.
+			tuned:               newTestTuned("t1", []tunedv1.TunedProfile{profileInvalidDataFrom}, nil),
+			expectedValid:       corev1.ConditionFalse,
+			expectedConflicting: corev1.ConditionFalse,
+			expectedInUse:       corev1.ConditionFalse,
+		},
+		{
+			// Missing ConfigMap key.
+			tuned:               newTestTuned("t1", []tunedv1.TunedProfile{profileMissingKey}, nil),
+			expectedValid:       corev1.ConditionFalse,
+			expectedConflicting: corev1.ConditionFalse,
+			expectedInUse:       corev1.ConditionFalse,
+		},
+		{
+			// Missing ConfigMap.
+			tuned:               newTestTuned("t1", []tunedv1.TunedProfile{profileMissingConfigMap}, nil),
+			expectedValid:       corev1.ConditionFalse,
+			expectedConflicting: corev1.ConditionFalse,
+			expectedInUse:       corev1.ConditionFalse,
+		},
+	}
+
+	for i, tc := range tests {
+		tunedList := append([]*tunedv1.Tuned{tc.tuned}, tc.tunedList...)
+		status := computeTunedStatus(tc.tuned, tunedList, tc.selected, configMaps)
+
+		for conditionType, expected := range map[tunedv1.TunedConditionType]corev1.ConditionStatus{
+			tunedv1.TunedValid:       tc.expectedValid,
+			tunedv1.TunedConflicting: tc.expectedConflicting,
+			tunedv1.TunedInUse:       tc.expectedInUse,
+		} {
+			if have := tunedConditionStatus(status.Conditions, conditionType); have != expected {
+				t.Errorf("failed test case %d: condition %s:\n\twant: %s\n\thave: %s", i+1, conditionType, expected, have)
+			}
+		}
+		if !reflect.DeepEqual(tc.expectedRecommend, status.Recommend) {
+			t.Errorf("failed test case %d:\n\twant: %+v\n\thave: %+v", i+1, tc.expectedRecommend, status.Recommend)
+		}
+	}
+}
+
+func TestComputeTunedStatusKeepsTransitionTime(t *testing.T) {
+	tuned := newTestTuned("t1", nil, nil)
+
+	status := computeTunedStatus(tuned, []*tunedv1.Tuned{tuned}, nil, nil)
+	tuned.Status = status
+	statusNew := computeTunedStatus(tuned, []*tunedv1.Tuned{tuned}, nil, nil)
+
+	if !reflect.DeepEqual(status, statusNew) {
+		t.Errorf("unchanged Tuned status recomputed:\n\twant: %+v\n\thave: %+v", status, statusNew)
+	}
+}
@@ -0,0 +1,87 @@
+package operator
+
+import (
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
+)
+
+// recommendCatchAll returns true if recommend item 'recommend' selects its TuneD
+// profile for every Node it is evaluated for.  On HyperShift, this means every
+// Node of the NodePools the recommend item's Tuned object is referenced by.
+func recommendCatchAll(recommend TunedRecommendInfo) bool {
+	if recommend.NodeSelector != nil || len(recommend.Match) > 0 {
+		return false
+	}
+	if ntoconfig.InHyperShift() {
+		// Both node/pod label matching with empty rules and NodePool based matching.
+		return true
+	}
+	// See calculateProfileFromTuneds(), machineConfigLabels are only considered
+	// if the match section is nil.
+	return recommend.Match != nil || recommend.MachineConfigLabels == nil
+}
+
+// recommendShadowedBy returns the recommend items of the active Tuned objects from
+// 'tunedList' which are unreachable, because a catch-all recommend item evaluated
+// before them always selects its TuneD profile first.  The result maps unreachable
+// recommend items to the first catch-all recommend item shadowing them.
+func recommendShadowedBy(tunedList []*tunedv1.Tuned) map[TunedRecommendSource]TunedRecommendSource {
+	var catchAll []TunedRecommendInfo
+
+	nodePools := map[string]string{}
+	for _, tuned := range tunedList {
+		nodePools[tuned.Name] = tuned.Labels[hypershiftNodePoolNameLabel]
+	}
+
+	shadowedBy := map[TunedRecommendSource]TunedRecommendSource{}
+	for _, recommend := range TunedRecommend(tunedsActive(tunedList)) {
+		for _, c := range catchAll {
+			// A catch-all recommend item of a Tuned object referenced by a NodePool
+			// only shadows recommend items evaluated for the same NodePool.
+			if nodePool := nodePools[c.Source.TunedName]; nodePool == "" || nodePool == nodePools[recommend.Source.TunedName] {
+				shadowedBy[recommend.Source] = c.Source
+				break
+			}
+		}
+		if _, ok := shadowedBy[recommend.Source]; !ok && recommendCatchAll(recommend) {
+			catchAll = append(catchAll, recommend)
+		}
+	}
+
+	return shadowedBy
+}
+
+// setTunedRecommendUnused sets the unused recommend item fields of Tuned status
+// 'status' for Tuned 'tuned' based on the unreachable recommend items 'shadowedBy'
+// and the number of Nodes the recommend items selected their TuneD profile for.
+// Recommend items matching no Node are only reported once 'profilesSynced' is true,
+// i.e. all Profiles were synced.  Returns the unused recommend items for metrics.
+func setTunedRecommendUnused(tuned *tunedv1.Tuned, status *tunedv1.TunedStatus, shadowedBy map[TunedRecommendSource]TunedRecommendSource, profilesSynced bool) []metrics.RecommendUnused {
+	var unused []metrics.RecommendUnused
+
+	if util.HasDryRunAnnotation(tuned.Annotations) {
+		// Tuned objects in the dry-run mode select no TuneD profiles.
+		return nil
+	}
+
+	for i := range status.Recommend {
+		recommendStatus := &status.Recommend[i]
+		if by, ok := shadowedBy[TunedRecommendSource{TunedName: tuned.Name, Index: i}]; ok {
+			recommendStatus.Unused = tunedv1.TunedRecommendUnreachable
+			recommendStatus.ShadowedBy = by.String()
+		} else if recommendStatus.MatchedNodes == 0 && profilesSynced {
+			recommendStatus.Unused = tunedv1.TunedRecommendNoMatch
+		} else {
+			continue
+		}
+		unused = append(unused, metrics.RecommendUnused{
+			Tuned:     tuned.Name,
+			Recommend: i,
+			Reason:    string(recommendStatus.Unused),
+		})
+	}
+
+	return unused
+}
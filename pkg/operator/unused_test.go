@@ -0,0 +1,181 @@
+package operator
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
+)
+
+func TestRecommendShadowedBy(t *testing.T) {
+	newTuned := func(name, nodePool string, recommend ...tunedv1.TunedRecommend) *tunedv1.Tuned {
+		tuned := &tunedv1.Tuned{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       tunedv1.TunedSpec{Recommend: recommend},
+		}
+		if nodePool != "" {
+			tuned.Labels = map[string]string{hypershiftNodePoolNameLabel: nodePool}
+		}
+		return tuned
+	}
+	catchAll := func(profile string, priority uint64) tunedv1.TunedRecommend {
+		return tunedv1.TunedRecommend{Profile: ptr.To(profile), Priority: ptr.To(priority)}
+	}
+	labelMatch := func(profile string, priority uint64) tunedv1.TunedRecommend {
+		return tunedv1.TunedRecommend{
+			Profile:  ptr.To(profile),
+			Priority: ptr.To(priority),
+			Match:    []tunedv1.TunedMatch{{Label: ptr.To("node-role.kubernetes.io/worker")}},
+		}
+	}
+
+	tests := []struct {
+		hyperShift bool
+		tunedList  []*tunedv1.Tuned
+		expected   map[TunedRecommendSource]TunedRecommendSource
+	}{
+		{
+			tunedList: []*tunedv1.Tuned{
+				newTuned("default", "", labelMatch("openshift-control-plane", 30), catchAll("openshift-node", 40)),
+				newTuned("custom", "", labelMatch("worker", 20), labelMatch("late", 50)),
+			},
+			expected: map[TunedRecommendSource]TunedRecommendSource{
+				{TunedName: "custom", Index: 1}: {TunedName: "default", Index: 1},
+			},
+		},
+		{
+			// A catch-all recommend item shadows recommend items of the same priority evaluated after it.
+			tunedList: []*tunedv1.Tuned{
+				newTuned("a", "", catchAll("a", 10)),
+				newTuned("b", "", catchAll("b", 10)),
+			},
+			expected: map[TunedRecommendSource]TunedRecommendSource{
+				{TunedName: "b", Index: 0}: {TunedName: "a", Index: 0},
+			},
+		},
+		{
+			// machineConfigLabels and nodeSelector restrict recommend items without match rules.
+			tunedList: []*tunedv1.Tuned{
+				newTuned("custom", "",
+					tunedv1.TunedRecommend{
+						Profile:             ptr.To("rt"),
+						Priority:            ptr.To[uint64](10),
+						MachineConfigLabels: map[string]string{"machineconfiguration.openshift.io/role": "worker-rt"},
+					},
+					tunedv1.TunedRecommend{
+						Profile:      ptr.To("infra"),
+						Priority:     ptr.To[uint64](20),
+						NodeSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"node-role.kubernetes.io/infra": ""}},
+					},
+					labelMatch("worker", 30),
+				),
+			},
+			expected: map[TunedRecommendSource]TunedRecommendSource{},
+		},
+		{
+			// Tuned objects in the dry-run mode are not evaluated.
+			tunedList: []*tunedv1.Tuned{
+				newTuned("default", "", catchAll("openshift-node", 40)),
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "dry", Annotations: map[string]string{tunedv1.TunedDryRun: "true"}},
+					Spec:       tunedv1.TunedSpec{Recommend: []tunedv1.TunedRecommend{catchAll("dry", 10)}},
+				},
+			},
+			expected: map[TunedRecommendSource]TunedRecommendSource{},
+		},
+		{
+			// On HyperShift, recommend items without match rules select the NodePool.
+			hyperShift: true,
+			tunedList: []*tunedv1.Tuned{
+				newTuned("default", "", catchAll("openshift-node", 40)),
+				newTuned("pool-a", "a", catchAll("a", 10), labelMatch("a-late", 20)),
+				newTuned("pool-b", "b", labelMatch("b", 20)),
+				newTuned("pool-b-late", "b", catchAll("b-late", 50)),
+			},
+			expected: map[TunedRecommendSource]TunedRecommendSource{
+				{TunedName: "pool-a", Index: 1}:      {TunedName: "pool-a", Index: 0},
+				{TunedName: "pool-b-late", Index: 0}: {TunedName: "default", Index: 0},
+			},
+		},
+	}
+
+	for i, tc := range tests {
+		if tc.hyperShift {
+			t.Setenv("HYPERSHIFT", "true")
+		}
+		have := recommendShadowedBy(tc.tunedList)
+		if !reflect.DeepEqual(have, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %+v\n\thave: %+v", i, tc.expected, have)
+		}
+	}
+}
+
+func TestSetTunedRecommendUnused(t *testing.T) {
+	tuned := &tunedv1.Tuned{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+	}
+	status := tunedv1.TunedStatus{
+		Recommend: []tunedv1.TunedRecommendStatus{
+			{Profile: "used", MatchedNodes: 2},
+			{Profile: "unmatched"},
+			{Profile: "shadowed"},
+		},
+	}
+	shadowedBy := map[TunedRecommendSource]TunedRecommendSource{
+		{TunedName: "custom", Index: 2}: {TunedName: "default", Index: 1},
+	}
+
+	unused := setTunedRecommendUnused(tuned, &status, shadowedBy, true)
+
+	expectedStatus := []tunedv1.TunedRecommendStatus{
+		{Profile: "used", MatchedNodes: 2},
+		{Profile: "unmatched", Unused: tunedv1.TunedRecommendNoMatch},
+		{Profile: "shadowed", Unused: tunedv1.TunedRecommendUnreachable, ShadowedBy: "default/recommend[1]"},
+	}
+	if !reflect.DeepEqual(status.Recommend, expectedStatus) {
+		t.Errorf("want: %+v\nhave: %+v", expectedStatus, status.Recommend)
+	}
+	expectedUnused := []metrics.RecommendUnused{
+		{Tuned: "custom", Recommend: 1, Reason: "NoMatch"},
+		{Tuned: "custom", Recommend: 2, Reason: "Unreachable"},
+	}
+	if !reflect.DeepEqual(unused, expectedUnused) {
+		t.Errorf("want: %+v\nhave: %+v", expectedUnused, unused)
+	}
+}
+
+func TestSetTunedRecommendUnusedProfilesNotSynced(t *testing.T) {
+	tuned := &tunedv1.Tuned{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom"},
+	}
+	status := tunedv1.TunedStatus{
+		Recommend: []tunedv1.TunedRecommendStatus{
+			{Profile: "unmatched"},
+			{Profile: "shadowed"},
+		},
+	}
+	shadowedBy := map[TunedRecommendSource]TunedRecommendSource{
+		{TunedName: "custom", Index: 1}: {TunedName: "default", Index: 1},
+	}
+
+	// Not all Profiles were synced yet, recommend items matching no Node may still match one.
+	unused := setTunedRecommendUnused(tuned, &status, shadowedBy, false)
+
+	expectedStatus := []tunedv1.TunedRecommendStatus{
+		{Profile: "unmatched"},
+		{Profile: "shadowed", Unused: tunedv1.TunedRecommendUnreachable, ShadowedBy: "default/recommend[1]"},
+	}
+	if !reflect.DeepEqual(status.Recommend, expectedStatus) {
+		t.Errorf("want: %+v\nhave: %+v", expectedStatus, status.Recommend)
+	}
+	expectedUnused := []metrics.RecommendUnused{
+		{Tuned: "custom", Recommend: 1, Reason: "Unreachable"},
+	}
+	if !reflect.DeepEqual(unused, expectedUnused) {
+		t.Errorf("want: %+v\nhave: %+v", expectedUnused, unused)
+	}
+}
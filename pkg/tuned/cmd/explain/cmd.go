@@ -0,0 +1,84 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"errors"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/klog/v2"
+)
+
+type explainOpts struct {
+	assetsInDir []string
+	nodeName    string
+}
+
+// NewExplainCommand creates the "explain" command explaining the TuneD profile
+// selection offline.
+func NewExplainCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "explain",
+		Short: "Explain the TuneD profile selection offline",
+	}
+
+	cmd.AddCommand(newExplainNodeCommand())
+	return cmd
+}
+
+func newExplainNodeCommand() *cobra.Command {
+	explainOpts := explainOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "node NODE",
+		Short: "Explain which recommend items select a TuneD profile for a Node and why",
+		Long: `Explain which recommend items select a TuneD profile for a Node and why.
+
+Tuned, Node, Pod and MachineConfigPool manifests (single objects or lists) are read
+from the input directories, for example from a must-gather.  All recommend items are
+printed in the order of their evaluation with the reason why they match the Node or not.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			explainOpts.nodeName = args[0]
+			if err := explainOpts.Validate(); err != nil {
+				klog.Fatal(err)
+			}
+
+			if err := explainOpts.Run(); err != nil {
+				klog.Fatal(err)
+			}
+		},
+	}
+
+	explainOpts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+func (e *explainOpts) AddFlags(fs *pflag.FlagSet) {
+	fs.StringArrayVar(&e.assetsInDir, "asset-input-dir", e.assetsInDir, "Input path for the manifests or must-gather directory. (Can use it more than one to define multiple directories)")
+}
+
+func (e *explainOpts) Validate() error {
+	if len(e.assetsInDir) == 0 {
+		return errors.New("asset-input-dir must be specified")
+	}
+	return nil
+}
+
+func (e *explainOpts) Run() error {
+	return explainNode(os.Stdout, e.assetsInDir, e.nodeName)
+}
@@ -0,0 +1,188 @@
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package explain
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/klog/v2"
+
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntomf "github.com/openshift/cluster-node-tuning-operator/pkg/manifests"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/operator"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
+)
+
+var (
+	manifestScheme = runtime.NewScheme()
+	runtimeDecoder runtime.Decoder
+)
+
+func init() {
+	utilruntime.Must(corev1.AddToScheme(manifestScheme))
+	utilruntime.Must(mcfgv1.Install(manifestScheme))
+	utilruntime.Must(tunedv1.AddToScheme(manifestScheme))
+	runtimeDecoder = serializer.NewCodecFactory(manifestScheme).UniversalDeserializer()
+}
+
+// manifests holds the objects relevant for the TuneD profile selection.
+type manifests struct {
+	tuneds []*tunedv1.Tuned
+	nodes  []*corev1.Node
+	pods   []*corev1.Pod
+	pools  []*mcfgv1.MachineConfigPool
+}
+
+// add adds object 'obj' to the manifests, expanding the lists.
+func (m *manifests) add(obj runtime.Object) error {
+	switch obj := obj.(type) {
+	case *tunedv1.Tuned:
+		m.tuneds = append(m.tuneds, obj)
+	case *tunedv1.TunedList:
+		for i := range obj.Items {
+			m.tuneds = append(m.tuneds, &obj.Items[i])
+		}
+	case *corev1.Node:
+		m.nodes = append(m.nodes, obj)
+	case *corev1.NodeList:
+		for i := range obj.Items {
+			m.nodes = append(m.nodes, &obj.Items[i])
+		}
+	case *corev1.Pod:
+		m.pods = append(m.pods, obj)
+	case *corev1.PodList:
+		for i := range obj.Items {
+			m.pods = append(m.pods, &obj.Items[i])
+		}
+	case *mcfgv1.MachineConfigPool:
+		m.pools = append(m.pools, obj)
+	case *mcfgv1.MachineConfigPoolList:
+		for i := range obj.Items {
+			m.pools = append(m.pools, &obj.Items[i])
+		}
+	case *corev1.List:
+		for _, item := range obj.Items {
+			itemObj, err := runtime.Decode(runtimeDecoder, item.Raw)
+			if err != nil {
+				if runtime.IsNotRegisteredError(err) || runtime.IsMissingKind(err) {
+					continue
+				}
+				return err
+			}
+			if err := m.add(itemObj); err != nil {
+				return err
+			}
+		}
+	default:
+		klog.V(4).Infof("skipping manifest because of unhandled %T", obj)
+	}
+
+	return nil
+}
+
+// loadManifests loads the manifests from the files in directories 'inputDir'.
+func loadManifests(inputDir []string) (*manifests, error) {
+	m := &manifests{}
+
+	filePaths, err := util.ListFilesFromMultiplePaths(inputDir)
+	if err != nil {
+		return nil, fmt.Errorf("error while listing files: %w", err)
+	}
+	var manifestPaths []string
+	for _, path := range filePaths {
+		switch filepath.Ext(path) {
+		case ".yaml", ".yml", ".json":
+			manifestPaths = append(manifestPaths, path)
+		default:
+			// Skip logs and other non-manifest files in must-gathers.
+		}
+	}
+	if err := util.DecodeManifestsFromFiles(manifestPaths, runtimeDecoder, m.add); err != nil {
+		return nil, err
+	}
+
+	for _, tuned := range m.tuneds {
+		if tuned.Name == tunedv1.TunedDefaultResourceName {
+			return m, nil
+		}
+	}
+	klog.Infof("no Tuned %s found in input directories, using the operator's default", tunedv1.TunedDefaultResourceName)
+	m.tuneds = append(m.tuneds, ntomf.TunedCustomResource())
+
+	return m, nil
+}
+
+// explainNode prints to 'w' the explanations which recommend items of the Tuned
+// objects from directories 'inputDir' select a TuneD profile for Node 'nodeName'.
+func explainNode(w io.Writer, inputDir []string, nodeName string) error {
+	m, err := loadManifests(inputDir)
+	if err != nil {
+		return err
+	}
+
+	pc, err := operator.NewOfflineProfileCalculator(m.tuneds, m.nodes, m.pods, m.pools)
+	if err != nil {
+		return err
+	}
+	explanations, err := pc.ExplainNode(nodeName)
+	if err != nil {
+		return err
+	}
+
+	return printExplanations(w, nodeName, explanations)
+}
+
+// printExplanations prints the explanations 'explanations' for Node 'nodeName' to 'w'.
+func printExplanations(w io.Writer, nodeName string, explanations []operator.RecommendExplanation) error {
+	var selected *operator.RecommendExplanation
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tRECOMMEND\tPROFILE\tPRIORITY\tRESULT\tREASON")
+	for i, e := range explanations {
+		priority := "<none>"
+		if e.Priority != nil {
+			priority = strconv.FormatUint(*e.Priority, 10)
+		}
+		result := "no match"
+		switch {
+		case e.Selected:
+			result = "SELECTED"
+			selected = &explanations[i]
+		case e.Matched:
+			result = "match"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\n", i+1, e.Source, e.Profile, priority, result, e.Reason)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if selected == nil {
+		_, err := fmt.Fprintf(w, "\nNo recommend item selects a TuneD profile for Node %s.\n", nodeName)
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\nNode %s: TuneD profile %s selected by %s.\n", nodeName, selected.Profile, selected.Source)
+	return err
+}
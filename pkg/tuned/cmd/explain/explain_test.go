@@ -0,0 +1,29 @@
+package explain
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestExplainNode(t *testing.T) {
+	var out bytes.Buffer
+
+	if err := explainNode(&out, []string{"testdata/must-gather"}, "node1"); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"elasticsearch/recommend[0]  elasticsearch   20        SELECTED  matched pod label tuned.openshift.io/elasticsearch",
+		"default/recommend[0]        openshift-node  40        match     catch-all item without match rules",
+		"Node node1: TuneD profile elasticsearch selected by elasticsearch/recommend[0].",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("want output containing %q, have:\n%s", want, out.String())
+		}
+	}
+
+	if err := explainNode(&out, []string{"testdata/must-gather"}, "node2"); err == nil {
+		t.Errorf("want error explaining a missing Node")
+	}
+}
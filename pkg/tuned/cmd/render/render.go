@@ -15,19 +15,18 @@ package render
 
 import (
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
-	assets "github.com/openshift/cluster-node-tuning-operator/assets/tuned"
 	performancev2 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/performanceprofile/v2"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/operator"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/components/tuned"
 	"sigs.k8s.io/yaml"
 
 	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	ntomf "github.com/openshift/cluster-node-tuning-operator/pkg/manifests"
 	tunedpkg "github.com/openshift/cluster-node-tuning-operator/pkg/tuned"
 
 	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
@@ -60,51 +59,32 @@ func init() {
 	)
 }
 
-func decodeManifestsFromFile(filename string,
-	r io.Reader,
-	runtimeDecoder runtime.Decoder,
-	perfProfiles *[]*performancev2.PerformanceProfile,
+// manifestAdder returns a function adding the decoded objects to the slices passed.
+func manifestAdder(perfProfiles *[]*performancev2.PerformanceProfile,
 	mcPools *[]*mcfgv1.MachineConfigPool,
 	mcConfigs *[]*mcfgv1.MachineConfig,
-	tuneD *[]*tunedv1.Tuned) error {
-	manifests, err := util.ParseManifests(filename, r)
-	if err != nil {
-		return fmt.Errorf("error parsing manifests from %s: %w", filename, err)
-	}
-
-	// Decode manifest files
-	klog.V(4).Infof("decoding manifests for file %s...", filename)
-	for idx, m := range manifests {
-		obji, err := runtime.Decode(runtimeDecoder, m.Raw)
-		if err != nil {
-			if runtime.IsNotRegisteredError(err) {
-				klog.Infof("skipping path %q [%d] manifest because it is not part of expected api group: %v", filename, idx+1, err)
-				continue
-			}
-			klog.Errorf("error parsing %q [%d] manifest: %v", filename, idx+1, err)
-			return fmt.Errorf("error parsing %q [%d] manifest: %w", filename, idx+1, err)
-		}
-
+	tuneD *[]*tunedv1.Tuned) func(runtime.Object) error {
+	return func(obji runtime.Object) error {
 		switch obj := obji.(type) {
 		case *performancev2.PerformanceProfile:
-			klog.Infof("Adding PerformanceProfile Manifest %q from %q", obj.Name, filename)
+			klog.Infof("Adding PerformanceProfile Manifest %q", obj.Name)
 			*perfProfiles = append(*perfProfiles, obj)
 		case *mcfgv1.MachineConfigPool:
-			klog.Infof("Adding MachineConfigPool Manifest %q from %q", obj.Name, filename)
+			klog.Infof("Adding MachineConfigPool Manifest %q", obj.Name)
 			*mcPools = append(*mcPools, obj)
 		case *mcfgv1.MachineConfig:
-			klog.Infof("Adding MachineConfig Manifest %q from %q", obj.Name, filename)
+			klog.Infof("Adding MachineConfig Manifest %q", obj.Name)
 			*mcConfigs = append(*mcConfigs, obj)
 		case *tunedv1.Tuned:
-			klog.Infof("Adding TuneD Manifest %q from %q", obj.Name, filename)
+			klog.Infof("Adding TuneD Manifest %q", obj.Name)
 			*tuneD = append(*tuneD, obj)
 		default:
-			klog.V(4).Infof("skipping %q [%d] manifest because of unhandled %T", filename, idx+1, obji)
+			klog.V(4).Infof("skipping manifest because of unhandled %T", obji)
 		}
+		return nil
 	}
-
-	return nil
 }
+
 func render(inputDir []string, outputDir string, mcpName string) error {
 	klog.Info("Rendering files from: ", inputDir)
 	klog.Info("Rendering files into: ", outputDir)
@@ -137,17 +117,9 @@ func render(inputDir []string, outputDir string, mcpName string) error {
 
 	// Iterate through the file paths and read in desired files
 	klog.Info("Iterating over listed files ... ")
-	for _, path := range filePaths {
-		file, err := os.Open(path)
-		if err != nil {
-			return fmt.Errorf("error opening %s: %w", file.Name(), err)
-		}
-		defer file.Close()
-
-		err = decodeManifestsFromFile(file.Name(), file, runtimeDecoder, &perfProfiles, &mcPools, &mcConfigs, &tuneD)
-		if err != nil {
-			return err
-		}
+	err = util.DecodeManifestsFromFiles(filePaths, runtimeDecoder, manifestAdder(&perfProfiles, &mcPools, &mcConfigs, &tuneD))
+	if err != nil {
+		return err
 	}
 
 	if len(perfProfiles) == 0 {
@@ -185,11 +157,8 @@ func render(inputDir []string, outputDir string, mcpName string) error {
 
 	klog.Infof("working with %d additional tuneD profiles", len(tuneD))
 
-	if err := loadDefaultCrTuneD(&tuneD); err != nil {
-		e := fmt.Errorf("unable to load default cr tuned %w", err)
-		klog.Error(e)
-		return e
-	}
+	klog.Infof("Unable to get tuned recommended profile with current info. Adding default tuneD")
+	tuneD = append(tuneD, ntomf.TunedCustomResource())
 
 	if err := tunedpkg.TunedRsyncEtc(); err != nil {
 		e := fmt.Errorf("unable to prepare /etc/tuned directory: %w", err)
@@ -291,29 +260,6 @@ func render(inputDir []string, outputDir string, mcpName string) error {
 	return nil
 }
 
-func loadDefaultCrTuneD(tuneD *[]*tunedv1.Tuned) error {
-	var (
-		dummyPerfProfiles []*performancev2.PerformanceProfile
-		dummyMcPools      []*mcfgv1.MachineConfigPool
-		dummyMcConfigs    []*mcfgv1.MachineConfig
-	)
-
-	klog.Infof("Unable to get tuned recommended profile with current info. Adding default tuneD")
-
-	fileName := "default-cr-tuned.yaml"
-	f, err := assets.Manifests.Open(filepath.Join("manifests", fileName))
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
-	err = decodeManifestsFromFile(fileName, f, runtimeDecoder, &dummyPerfProfiles, &dummyMcPools, &dummyMcConfigs, tuneD)
-	if err != nil {
-		return err
-	}
-	return nil
-}
-
 func renderMachineConfig(pool *mcfgv1.MachineConfigPool, bootcmdline string, mConfigs []*mcfgv1.MachineConfig, mcLabels map[string]string) (*mcfgv1.MachineConfig, error) {
 	if len(bootcmdline) == 0 {
 		klog.Info("Empty cmdbootline. Avoid creating MachineConfig")
@@ -0,0 +1,104 @@
+package tuned
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
+)
+
+// procCmdline is the kernel command-line the running kernel was booted with.
+const procCmdline = "/proc/cmdline"
+
+// runningKernelArguments returns the kernel command-line of the running kernel.
+func runningKernelArguments() (string, error) {
+	cmdline, err := os.ReadFile(procCmdline)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %v", procCmdline, err)
+	}
+	return strings.TrimSpace(string(cmdline)), nil
+}
+
+// kernelArgumentsCompare compares the kernel arguments 'bootcmdline' calculated by
+// TuneD with the kernel command-line of the running kernel.  The arguments of the
+// previously calculated kernel arguments 'previous' are taken into account too,
+// so that arguments TuneD no longer calculates are reported.
+func (c *Controller) kernelArgumentsCompare(bootcmdline, previous string) {
+	running, err := runningKernelArguments()
+	if err != nil {
+		klog.Errorf("unable to compare kernel command-line parameters: %v", err)
+		c.daemon.kernelArgs.known = false
+		return
+	}
+
+	c.daemon.kernelArgs.known = true
+	c.daemon.kernelArgs.missing, c.daemon.kernelArgs.extra = kernelArgumentsDiff(bootcmdline, previous, running)
+	if len(c.daemon.kernelArgs.missing) > 0 || len(c.daemon.kernelArgs.extra) > 0 {
+		klog.V(2).Infof("kernelArgumentsCompare(): reboot required; missing %q, extra %q", c.daemon.kernelArgs.missing, c.daemon.kernelArgs.extra)
+	}
+}
+
+// kernelArgumentKey returns the name of kernel argument 'arg'.
+func kernelArgumentKey(arg string) string {
+	if i := strings.Index(arg, "="); i >= 0 {
+		return arg[:i]
+	}
+	return arg
+}
+
+// kernelArgumentsDiff compares the kernel arguments 'expected' by the TuneD profile
+// with the kernel command-line 'running'.  Only the arguments of 'running' named
+// in 'expected' or in the previously expected kernel arguments 'previous' are taken
+// into account, as the kernel command-line carries many arguments not managed by
+// TuneD.  Returns the 'expected' arguments missing from 'running' and the 'running'
+// arguments not 'expected'.  When the arguments only differ in their order, which
+// matters for some of them, all the arguments are reported as both missing and extra.
+func kernelArgumentsDiff(expected, previous, running string) (missing []string, extra []string) {
+	expectedArgs := util.SplitKernelArguments(expected)
+	managedKeys := make(map[string]bool, len(expectedArgs))
+	for _, arg := range expectedArgs {
+		managedKeys[kernelArgumentKey(arg)] = true
+	}
+	for _, arg := range util.SplitKernelArguments(previous) {
+		managedKeys[kernelArgumentKey(arg)] = true
+	}
+
+	var runningArgs []string
+	for _, arg := range util.SplitKernelArguments(running) {
+		if managedKeys[kernelArgumentKey(arg)] {
+			runningArgs = append(runningArgs, arg)
+		}
+	}
+
+	if util.KernelArgumentsEqual(expected, strings.Join(runningArgs, " ")) {
+		return nil, nil
+	}
+
+	missing = kernelArgumentsMissing(expectedArgs, runningArgs)
+	extra = kernelArgumentsMissing(runningArgs, expectedArgs)
+	if len(missing) == 0 && len(extra) == 0 {
+		// Same arguments in a different order.
+		return expectedArgs, runningArgs
+	}
+
+	return missing, extra
+}
+
+// kernelArgumentsMissing returns the arguments of 'args' missing from 'from'.
+func kernelArgumentsMissing(args, from []string) []string {
+	present := make(map[string]bool, len(from))
+	for _, arg := range from {
+		present[arg] = true
+	}
+
+	var missing []string
+	for _, arg := range args {
+		if !present[arg] {
+			missing = append(missing, arg)
+		}
+	}
+	return missing
+}
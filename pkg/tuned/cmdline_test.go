@@ -0,0 +1,87 @@
+package tuned
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKernelArgumentsDiff(t *testing.T) {
+	const running = "BOOT_IMAGE=(hd0,gpt3)/vmlinuz root=UUID=1234 rw skew_tick=1 nohz_full=1-2 hugepagesz=1G hugepages=4 hugepagesz=2M hugepages=128"
+
+	testCases := []struct {
+		name            string
+		expected        string
+		previous        string
+		running         string
+		expectedMissing []string
+		expectedExtra   []string
+	}{
+		{
+			name:     "empty",
+			expected: "",
+			running:  running,
+		},
+		{
+			name:     "match",
+			expected: "skew_tick=1 nohz_full=1-2",
+			running:  running,
+		},
+		{
+			name:            "missing",
+			expected:        "skew_tick=1 nohz_full=1-2 intel_pstate=disable",
+			running:         running,
+			expectedMissing: []string{"intel_pstate=disable"},
+		},
+		{
+			name:            "changed",
+			expected:        "skew_tick=1 nohz_full=1-3",
+			running:         running,
+			expectedMissing: []string{"nohz_full=1-3"},
+			expectedExtra:   []string{"nohz_full=1-2"},
+		},
+		{
+			name:     "order-match",
+			expected: "hugepagesz=1G hugepages=4 hugepagesz=2M hugepages=128",
+			running:  running,
+		},
+		{
+			name:            "order",
+			expected:        "hugepagesz=2M hugepages=128 hugepagesz=1G hugepages=4",
+			running:         running,
+			expectedMissing: []string{"hugepagesz=2M", "hugepages=128", "hugepagesz=1G", "hugepages=4"},
+			expectedExtra:   []string{"hugepagesz=1G", "hugepages=4", "hugepagesz=2M", "hugepages=128"},
+		},
+		{
+			name:          "removed",
+			expected:      "skew_tick=1",
+			previous:      "skew_tick=1 nohz_full=1-2",
+			running:       running,
+			expectedExtra: []string{"nohz_full=1-2"},
+		},
+		{
+			name:          "removed-all",
+			expected:      "",
+			previous:      "skew_tick=1 nohz_full=1-2",
+			running:       running,
+			expectedExtra: []string{"skew_tick=1", "nohz_full=1-2"},
+		},
+		{
+			name:     "removed-rebooted",
+			expected: "skew_tick=1",
+			previous: "skew_tick=1 intel_pstate=disable",
+			running:  running,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			missing, extra := kernelArgumentsDiff(tt.expected, tt.previous, tt.running)
+			if !reflect.DeepEqual(missing, tt.expectedMissing) {
+				t.Errorf("missing got=%#v expected=%#v", missing, tt.expectedMissing)
+			}
+			if !reflect.DeepEqual(extra, tt.expectedExtra) {
+				t.Errorf("extra got=%#v expected=%#v", extra, tt.expectedExtra)
+			}
+		})
+	}
+}
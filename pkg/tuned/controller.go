@@ -6,12 +6,14 @@ import (
 	"context" // context.TODO()
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"errors"  // errors.Is()
 	"fmt"     // Printf()
 	"math"    // math.Pow()
 	"os"      // os.Exit(), os.Stderr, ...
 	"os/exec" // os.Exec()
 	"path/filepath"
+	"reflect"
 	"sort"
 	"strings" // strings.Join()
 	"syscall" // syscall.SIGHUP, ...
@@ -27,6 +29,7 @@ import (
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 
@@ -35,6 +38,7 @@ import (
 	ntoconfig "github.com/openshift/cluster-node-tuning-operator/pkg/config"
 	tunedset "github.com/openshift/cluster-node-tuning-operator/pkg/generated/clientset/versioned"
 	tunedinformers "github.com/openshift/cluster-node-tuning-operator/pkg/generated/informers/externalversions"
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
 	"github.com/openshift/cluster-node-tuning-operator/version"
 )
@@ -107,6 +111,10 @@ const (
 	// and restarting the tuned daemon.
 	tunedDeferredUpdateEphemeralFilePath  = ocpTunedRunDir + "/pending_profile"
 	tunedDeferredUpdatePersistentFilePath = ocpTunedHome + "/pending_profile"
+	// The fingerprint of the discarded deferred update, so that it is not applied after operand or node restarts.
+	tunedDiscardedDeferredFilePath = ocpTunedHome + "/discarded_profile"
+	// The last good TuneD profile set and the rollback state, so that they survive operand restarts.
+	tunedRollbackStateFilePath = ocpTunedHome + "/good_profile"
 )
 
 // Types
@@ -119,6 +127,8 @@ type Daemon struct {
 	status Bits
 	// stderr log from TuneD daemon to report back via API.
 	stderr string
+	// messages are all the warnings and errors the TuneD daemon issued since its last (re)load.
+	messages tunedLogMessages
 	// stopping is true while the controller tries to stop the TuneD daemon.
 	stopping bool
 	// recommendedProfile is the TuneD profile the operator calculated to be applied.
@@ -133,6 +143,54 @@ type Daemon struct {
 	// recoveredRecommendedProfile is the TuneD profile which we detected to be in effect.
 	// Relevant in the deferred updates flow.
 	recoveredRecommendedProfile string
+	// revisionUnpacked is the revision of the rendered profile set unpacked on the node.
+	revisionUnpacked string
+	// revisionEffective is the revision of the rendered profile set effective on the node.
+	revisionEffective string
+	// rollbackOnDegraded is true if the operator requested reverting to the last good
+	// TuneD profile when the TuneD daemon reports errors applying a new one.
+	rollbackOnDegraded bool
+	// good is the last TuneD profile set which the TuneD daemon applied cleanly.
+	good struct {
+		profiles           []tunedv1.TunedProfile
+		recommendedProfile string
+		fingerprint        string
+		revision           string
+	}
+	// effective is the TuneD profile set the TuneD daemon applied last.
+	effective struct {
+		profiles           []tunedv1.TunedProfile
+		recommendedProfile string
+		fingerprint        string
+		revision           string
+	}
+	// discardedDeferred is the fingerprint of the discarded deferred update which is
+	// kept from being applied; empty if there is no such update.
+	discardedDeferred string
+	// deferredDiff is the summary of the differences between the TuneD profile set of
+	// the pending deferred update and the effective TuneD profile set.
+	deferredDiff []string
+	// rolledBackFrom is the fingerprint of the profile the TuneD daemon reported
+	// errors for and which was rolled back from; empty if there was no rollback.
+	rolledBackFrom string
+	// rolledBackStderr is the TuneD daemon error which caused the rollback.
+	rolledBackStderr string
+	// verifyInterval is the interval of verifying the active profile settings still match the node state.
+	verifyInterval time.Duration
+	// drifted are the settings of the active profile which no longer match the node state.
+	drifted []string
+	// kernelArgs are the kernel arguments calculated by TuneD compared with the running
+	// kernel command-line; known is false if the comparison failed.
+	kernelArgs struct {
+		known   bool
+		missing []string
+		extra   []string
+		// previous are the kernel arguments calculated by TuneD before the current ones.
+		previous string
+	}
+	// reloadStarted is the time of the last TuneD daemon reload or restart request;
+	// zero once the TuneD daemon finished reloading.
+	reloadStarted time.Time
 }
 
 type Change struct {
@@ -147,6 +205,9 @@ type Change struct {
 	// Is this Change caused by a node restart?
 	nodeRestart bool
 
+	// Is this Change caused by a periodic verification of the active profile?
+	verify bool
+
 	// The following keys are set when profile == true.
 	// Was debugging set in Profile k8s object?
 	debug bool
@@ -154,16 +215,30 @@ type Change struct {
 	provider string
 	// Should we turn the reapply_sysctl TuneD option on in tuned-main.conf file?
 	reapplySysctl bool
+	// Should we revert to the last good TuneD profile on TuneD daemon errors?
+	rollbackOnDegraded bool
+	// Interval of verifying the active profile settings still match the node state.
+	verifyInterval time.Duration
 	// The current recommended profile as calculated by the operator.
 	recommendedProfile string
 
 	// Mode of the deferred update. deferredMode == util.DeferNever if this is a change
 	// triggered by an object without deferred annotation, which is the default.
 	deferredMode util.DeferMode
+	// Maintenance window of the deferred update if deferredMode requests one, nil otherwise.
+	deferWindow *util.DeferWindow
+	// Fingerprint of the pending deferred update to discard, if any.
+	discardDeferred string
 	// Text to convey in status message, if present.
 	message string
 }
 
+// deferWindowOpen returns true if the Change is deferred until a maintenance window
+// which is open at time 'now'.
+func (ch Change) deferWindowOpen(now time.Time) bool {
+	return ch.deferWindow != nil && ch.deferWindow.Open(now)
+}
+
 func (ch Change) String() string {
 	var items []string
 	if ch.profile {
@@ -178,6 +253,9 @@ func (ch Change) String() string {
 	if ch.nodeRestart {
 		items = append(items, "nodeRestart:true")
 	}
+	if ch.verify {
+		items = append(items, "verify:true")
+	}
 	if ch.debug {
 		items = append(items, "debug:true")
 	}
@@ -187,12 +265,24 @@ func (ch Change) String() string {
 	if ch.reapplySysctl {
 		items = append(items, "reapplySysctl:true")
 	}
+	if ch.rollbackOnDegraded {
+		items = append(items, "rollbackOnDegraded:true")
+	}
+	if ch.verifyInterval != 0 {
+		items = append(items, fmt.Sprintf("verifyInterval:%d", ch.verifyInterval))
+	}
 	if ch.recommendedProfile != "" {
 		items = append(items, fmt.Sprintf("recommendedProfile:%q", ch.recommendedProfile))
 	}
 	if ch.deferredMode != "" {
 		items = append(items, fmt.Sprintf("deferredMode:%q", string(ch.deferredMode)))
 	}
+	if ch.deferWindow != nil {
+		items = append(items, fmt.Sprintf("deferWindow:%q", ch.deferWindow.String()))
+	}
+	if ch.discardDeferred != "" {
+		items = append(items, fmt.Sprintf("discardDeferred:%q", ch.discardDeferred))
+	}
 	if ch.message != "" {
 		items = append(items, fmt.Sprintf("message:%q", ch.message))
 	}
@@ -210,6 +300,8 @@ type Controller struct {
 	listers *ntoclient.Listers
 	clients *ntoclient.Clients
 
+	recorder record.EventRecorder // records Events on the Profile and Node objects
+
 	daemon Daemon
 
 	nodeName string
@@ -220,6 +312,7 @@ type Controller struct {
 	changeCh     chan Change     // bi-directional channel to wake-up the main thread to process accrued changes
 	changeChRet  chan bool       // bi-directional channel to announce success/failure of change processing
 	tunedMainCfg *ini.File       // global TuneD configuration as defined in tuned-main.conf
+	verifyTicker *time.Ticker    // ticker for periodic verification of the active profile (if enabled)
 
 	pendingChange *Change // pending deferred change to be applied on node restart (if any)
 }
@@ -288,6 +381,7 @@ func newController(nodeName string, stopCh <-chan struct{}) (*Controller, error)
 		kubeclient:  kubeclient,
 		listers:     listers,
 		clients:     clients,
+		recorder:    ntoclient.NewEventRecorder(kubeclient, programName, nodeName),
 		tunedExit:   make(chan bool),
 		stopCh:      stopCh,
 		changeCh:    make(chan Change),
@@ -362,7 +456,12 @@ func (c *Controller) sync(key wqKeyKube) error {
 		if profile.Spec.Config.TuneDConfig.ReapplySysctl != nil {
 			change.reapplySysctl = *profile.Spec.Config.TuneDConfig.ReapplySysctl
 		}
-		change.deferredMode = util.GetDeferredUpdateAnnotation(profile.Annotations)
+		change.rollbackOnDegraded = profile.Spec.Config.RollbackOnDegraded
+		if profile.Spec.Config.VerifyInterval != nil {
+			change.verifyInterval = profile.Spec.Config.VerifyInterval.Duration
+		}
+		change.deferredMode, change.deferWindow = util.ParseDeferredUpdateAnnotation(profile.Annotations)
+		change.discardDeferred = util.GetDiscardDeferredUpdateAnnotation(profile.Annotations)
 		// Notify the event processor that the Profile k8s object containing information about which TuneD profile to apply changed.
 		c.wqTuneD.Add(wqKeyTuned{kind: wqKindDaemon, change: change})
 
@@ -820,10 +919,13 @@ func (c *Controller) tunedReload() error {
 	c.daemon.status = 0 // clear the set out of which Profile status conditions are created
 	c.daemon.status |= scReloading
 	c.daemon.stderr = ""
+	c.daemon.messages = nil
 	c.daemon.restart &= ^ctrlReload
+	c.daemon.reloadStarted = time.Now()
 
 	tunedStart := func() {
 		c.tunedCmd = c.tunedCreateCmd()
+		metrics.TunedRestarted()
 		go c.tunedRun()
 	}
 
@@ -848,6 +950,7 @@ func (c *Controller) tunedReload() error {
 			}
 			return fmt.Errorf("error sending SIGHUP to PID %d: %v\n", c.tunedCmd.Process.Pid, err)
 		}
+		metrics.TunedReloaded()
 	} else {
 		// This should never happen!
 		return fmt.Errorf("cannot find the TuneD process!")
@@ -940,7 +1043,140 @@ func (c *Controller) changeSyncerPostReloadOrRestart(change Change) {
 	klog.V(2).Infof("changeSyncerPostReloadOrRestart(): current effective profile fingerprint %q -> %q", c.daemon.profileFingerprintEffective, profileFP)
 
 	c.daemon.profileFingerprintEffective = profileFP
+	c.daemon.effective.profiles = profiles
+	c.daemon.effective.recommendedProfile = recommended
+	c.daemon.effective.fingerprint = profileFP
+	c.daemon.drifted = nil // the TuneD daemon just (re)applied the profile
+	if change.tunedReload && !c.daemon.reloadStarted.IsZero() {
+		metrics.TunedProfileApplied(time.Since(c.daemon.reloadStarted).Seconds())
+		c.daemon.reloadStarted = time.Time{}
+		if (c.daemon.status & scError) != 0 {
+			metrics.TunedFailed()
+		}
+	}
+	if c.daemon.profileFingerprintUnpacked == profileFP {
+		c.daemon.revisionEffective = c.daemon.revisionUnpacked
+	}
+	c.daemon.effective.revision = c.daemon.revisionEffective
+	c.daemon.deferredDiff = nil
 	c.daemon.status &= ^scDeferred // force clear even if it was never set.
+	if err := removeDeferredUpdate(); err != nil {
+		klog.Errorf("failed to clear the pending deferred update: %v", err)
+	}
+}
+
+// changeSyncerRollback keeps track of the last TuneD profile set the TuneD daemon
+// applied cleanly after a TuneD reload.  If the TuneD daemon reported errors applying
+// the current TuneD profile set and the rollback was requested, the last good TuneD
+// profile set is restored on disk and a TuneD reload is requested.  Returns true
+// if the rollback was initiated.
+func (c *Controller) changeSyncerRollback(change Change) (bool, error) {
+	if !change.tunedReload {
+		return false, nil
+	}
+
+	effectiveFP := c.daemon.profileFingerprintEffective
+	degraded := (c.daemon.status & (scError | scSysctlOverride)) != 0
+	if !degraded {
+		if (c.daemon.status&scApplied) == 0 || effectiveFP == c.daemon.good.fingerprint {
+			return false, nil
+		}
+		profiles, recommended, err := profilesRepackPath(tunedRecommendFile, tunedProfilesDirCustom)
+		if err != nil {
+			klog.Errorf("failed to record the last good TuneD profile: %v", err)
+			return false, nil
+		}
+		klog.V(2).Infof("changeSyncerRollback(): last good TuneD profile %q fingerprint %q", recommended, effectiveFP)
+		c.daemon.good.profiles = profiles
+		c.daemon.good.recommendedProfile = recommended
+		c.daemon.good.fingerprint = effectiveFP
+		c.daemon.good.revision = c.daemon.revisionEffective
+		// A new TuneD profile set applied cleanly, the previous rollback is over.
+		c.daemon.rolledBackFrom = ""
+		c.daemon.rolledBackStderr = ""
+		if err := c.storeRollbackState(); err != nil {
+			klog.Errorf("failed to store the last good TuneD profile: %v", err)
+		}
+		return false, nil
+	}
+
+	if !c.daemon.rollbackOnDegraded || c.daemon.good.fingerprint == "" || c.daemon.good.fingerprint == effectiveFP {
+		return false, nil
+	}
+
+	klog.Infof("TuneD daemon reported errors applying TuneD profile %q, rolling back to %q", c.daemon.recommendedProfile, c.daemon.good.recommendedProfile)
+	c.daemon.rolledBackFrom = effectiveFP
+	c.daemon.rolledBackStderr = c.daemonStatusMessage(scError)
+	if err := c.storeRollbackState(); err != nil {
+		klog.Errorf("failed to store the rollback state: %v", err)
+	}
+
+	if _, _, err := profilesSync(c.daemon.good.profiles, c.daemon.good.recommendedProfile); err != nil {
+		return false, err
+	}
+	if err := TunedRecommendFileWrite(c.daemon.good.recommendedProfile); err != nil {
+		return false, err
+	}
+	c.daemon.recommendedProfile = c.daemon.good.recommendedProfile
+	c.daemon.profileFingerprintUnpacked = c.daemon.good.fingerprint
+	c.daemon.revisionUnpacked = c.daemon.good.revision
+	c.daemon.restart |= ctrlReload
+
+	return true, nil
+}
+
+// verifyReset (re)starts the periodic verification of the active profile with
+// interval 'interval' or stops it if 'interval' is not positive.
+func (c *Controller) verifyReset(interval time.Duration) {
+	if interval == c.daemon.verifyInterval && (c.verifyTicker != nil) == (interval > 0) {
+		return
+	}
+	if c.verifyTicker != nil {
+		c.verifyTicker.Stop()
+		c.verifyTicker = nil
+	}
+	c.daemon.verifyInterval = interval
+	c.daemon.drifted = nil
+	if interval <= 0 {
+		return
+	}
+	klog.Infof("verifying the active profile every %v", interval)
+	c.verifyTicker = time.NewTicker(interval)
+}
+
+// verifyTickerC returns the channel of the verification ticker or nil if the
+// periodic verification is off.  Receiving from a nil channel blocks forever.
+func (c *Controller) verifyTickerC() <-chan time.Time {
+	if c.verifyTicker == nil {
+		return nil
+	}
+	return c.verifyTicker.C
+}
+
+// changeSyncerVerify verifies the sysctl and sysfs settings of the active profile
+// still match the node state if requested by 'change'.
+func (c *Controller) changeSyncerVerify(change Change) {
+	if !change.verify || c.daemon.verifyInterval <= 0 {
+		return
+	}
+	if (c.daemon.status & scApplied) == 0 {
+		// Nothing to verify, the profile has not been applied (yet).
+		c.daemon.drifted = nil
+		return
+	}
+
+	activeProfile, err := getActiveProfile()
+	if err != nil {
+		klog.Errorf("failed to verify the active profile: %v", err)
+		return
+	}
+
+	c.daemon.drifted = verifyProfile(activeProfile)
+	if len(c.daemon.drifted) > 0 {
+		klog.Infof("settings of the active profile %q drifted: %s", activeProfile, driftedMessage(c.daemon.drifted))
+	} else {
+		klog.V(2).Infof("changeSyncerVerify(): no drift of the active profile %q", activeProfile)
+	}
 }
 
 func (c *Controller) changeSyncerProfileStatus(change Change) (synced bool) {
@@ -973,6 +1209,7 @@ func (c *Controller) changeSyncerTuneD(change Change) (synced bool, err error) {
 	var inplaceUpdate bool // updating a profile already recommended
 	var cfgUpdated bool
 	var changeRecommend bool
+	var keepUnpacked bool // keep the TuneD profile set unpacked on disk
 
 	restart = change.nodeRestart
 	reload = change.nodeRestart
@@ -988,6 +1225,68 @@ func (c *Controller) changeSyncerTuneD(change Change) (synced bool, err error) {
 
 	// Check whether reload of the TuneD daemon is really necessary due to a Profile change.
 	if change.profile {
+		c.daemon.rollbackOnDegraded = change.rollbackOnDegraded
+		c.verifyReset(change.verifyInterval)
+		if c.daemon.rolledBackFrom != "" {
+			if !change.rollbackOnDegraded {
+				klog.Infof("rollback on degradation disabled, dropping the rollback state")
+				c.daemon.rolledBackFrom = ""
+				c.daemon.rolledBackStderr = ""
+				if err := c.storeRollbackState(); err != nil {
+					klog.Errorf("failed to store the rollback state: %v", err)
+				}
+			} else if !change.nodeRestart {
+				profile, err := c.listers.TunedProfiles.Get(c.nodeName)
+				if err != nil {
+					return false, fmt.Errorf("failed to get Profile %s: %v", c.nodeName, err)
+				}
+				if profilesFingerprint(profile.Spec.Profile, change.recommendedProfile) == c.daemon.rolledBackFrom {
+					// Do not reapply the TuneD profile we rolled back from.
+					klog.V(1).Infof("TuneD profile %q was rolled back from, keeping the last good TuneD profile %q", change.recommendedProfile, c.daemon.good.recommendedProfile)
+					if err = c.updateTunedProfile(change); err != nil {
+						klog.Error(err.Error())
+						return false, nil // retry later
+					}
+					return true, nil
+				}
+			}
+		}
+
+		if !change.nodeRestart && (change.discardDeferred != "" || c.daemon.discardedDeferred != "") {
+			profile, err := c.listers.TunedProfiles.Get(c.nodeName)
+			if err != nil {
+				return false, fmt.Errorf("failed to get Profile %s: %v", c.nodeName, err)
+			}
+			profileFP := profilesFingerprint(profile.Spec.Profile, change.recommendedProfile)
+			switch {
+			case util.IsImmediateUpdate(change.deferredMode) || profileFP != change.discardDeferred:
+				// The discarded deferred update, if any, was superseded.
+				if err = c.forgetDiscardedDeferredUpdate(); err != nil {
+					return false, err
+				}
+			case profileFP == c.daemon.discardedDeferred:
+				// The deferred update was discarded before, possibly before the operand or node
+				// restarted.  Keep the TuneD profile set unpacked on disk.
+				recommended, err := TunedRecommendFileRead()
+				if err != nil {
+					return false, err
+				}
+				klog.V(1).Infof("deferred update %q was discarded, keeping TuneD profile %q", profileFP, recommended)
+				change.recommendedProfile = recommended
+				keepUnpacked = true
+			case c.daemon.effective.fingerprint != "" && change.discardDeferred != c.daemon.effective.fingerprint:
+				// Do not unpack the TuneD profile set of the discarded deferred update.
+				if err = c.discardDeferredUpdate(change.discardDeferred); err != nil {
+					return false, err
+				}
+				if err = c.updateTunedProfile(change); err != nil {
+					klog.Error(err.Error())
+					return false, nil // retry later
+				}
+				return true, nil
+			}
+		}
+
 		changeProvider, err := providerSync(change.provider)
 		if err != nil {
 			return false, err
@@ -1023,6 +1322,9 @@ func (c *Controller) changeSyncerTuneD(change Change) (synced bool, err error) {
 		} else if util.IsImmediateUpdate(change.deferredMode) && (c.daemon.status&scDeferred != 0) {
 			klog.V(1).Infof("detected deferred update changed to immediate after object update")
 			reload = true
+		} else if change.deferWindowOpen(time.Now()) && (c.daemon.status&scDeferred != 0) {
+			klog.V(1).Infof("detected deferred update within maintenance window %q", change.deferredMode)
+			reload = true
 		} else {
 			klog.V(1).Infof("recommended profile (%s) matches current configuration", c.daemon.recommendedProfile)
 			// We do not need to reload the TuneD daemon, however, someone may have tampered with the k8s Profile status for this node.
@@ -1038,9 +1340,22 @@ func (c *Controller) changeSyncerTuneD(change Change) (synced bool, err error) {
 			return false, fmt.Errorf("failed to get Profile %s: %v", c.nodeName, err)
 		}
 
-		changeProfiles, profilesFP, err := profilesSync(profile.Spec.Profile, c.daemon.recommendedProfile)
-		if err != nil {
-			return false, err
+		changeProfiles, profilesFP := false, c.daemon.profileFingerprintUnpacked
+		if !keepUnpacked {
+			changeProfiles, profilesFP, err = profilesSync(profile.Spec.Profile, c.daemon.recommendedProfile)
+			if err != nil {
+				return false, err
+			}
+			c.daemon.revisionUnpacked = profile.Annotations[tunedv1.TunedRevisionAnnotationKey]
+		}
+		if !changeProfiles && !changeRecommend && profilesFP == c.daemon.profileFingerprintEffective &&
+			c.daemon.revisionEffective != c.daemon.revisionUnpacked {
+			// The revision of the rendered profile set changed, but the TuneD profiles in effect did not.
+			c.daemon.revisionEffective = c.daemon.revisionUnpacked
+			if err = c.updateTunedProfile(change); err != nil {
+				klog.Error(err.Error())
+				return false, nil // retry later
+			}
 		}
 		if changeProfiles || changeRecommend {
 			if c.daemon.profileFingerprintUnpacked != profilesFP {
@@ -1107,6 +1422,9 @@ func treatAsImmediate(change Change, inplaceUpdate bool) (bool, string) {
 	if !inplaceUpdate && change.deferredMode == util.DeferUpdate {
 		return true, "recommended profile change with deferredMode=" + util.DeferUpdate.String()
 	}
+	if change.deferWindowOpen(time.Now()) {
+		return true, "maintenance window " + change.deferredMode.String()
+	}
 	return false, ""
 }
 
@@ -1142,14 +1460,42 @@ func (c *Controller) handleDaemonReloadRestartRequest(change Change, reload, res
 		return err
 	}
 
+	if profiles, recommended, err := profilesRepackPath(tunedRecommendFile, tunedProfilesDirCustom); err != nil {
+		klog.Errorf("failed to summarize the deferred update: %v", err)
+		c.daemon.deferredDiff = nil
+	} else {
+		c.daemon.deferredDiff = profilesDiff(c.daemon.effective.profiles, c.daemon.effective.recommendedProfile, profiles, recommended)
+	}
+
 	// trigger status update
 	c.wqTuneD.Add(wqKeyTuned{kind: wqKindDaemon, change: Change{
 		profileStatus: true,
 		message:       fmt.Sprintf("status change for deferred update %q", change.recommendedProfile),
 	}})
+
+	c.scheduleDeferWindow(change)
 	return nil
 }
 
+// scheduleDeferWindow requeues the Profile of this node for processing when the next
+// maintenance window opens, so that a pending deferred update is applied within the
+// window without the need to restart the node.  Nothing is scheduled for deferred
+// updates which are not tied to a maintenance window.
+func (c *Controller) scheduleDeferWindow(change Change) {
+	if change.deferWindow == nil {
+		return
+	}
+	mode := change.deferredMode
+	now := time.Now()
+	next := change.deferWindow.Next(now)
+	if next.IsZero() {
+		klog.Warningf("maintenance window %q never opens; deferred update kept until next restart", mode)
+		return
+	}
+	klog.Infof("deferred update: scheduled for maintenance window %q opening at %s", mode, next.Format(time.RFC3339))
+	c.wqKube.AddAfter(wqKeyKube{kind: wqKindProfile, name: c.nodeName}, next.Sub(now))
+}
+
 func (c *Controller) changeSyncerRestartOrReloadTuneD() (bool, error) {
 	klog.V(2).Infof("changeSyncerRestartOrReloadTuneD()")
 	if (c.daemon.restart & ctrlRestart) != 0 {
@@ -1173,6 +1519,20 @@ func (c *Controller) changeSyncer(change Change) (bool, error) {
 	// Sync internal status after a node restart
 	c.changeSyncerPostReloadOrRestart(change)
 
+	// Verify the settings of the active profile still match the node state.
+	c.changeSyncerVerify(change)
+
+	// Revert to the last good TuneD profile if the current one degraded.
+	rollback, err := c.changeSyncerRollback(change)
+	if err != nil {
+		return false, err
+	}
+	if rollback {
+		// Profile status is updated once the TuneD daemon reloads the last good TuneD profile.
+		_, err = c.changeSyncerRestartOrReloadTuneD()
+		return err == nil, err
+	}
+
 	// Sync k8s Profile status if/when needed.
 	if !c.changeSyncerProfileStatus(change) {
 		return false, nil
@@ -1280,6 +1640,19 @@ func (c *Controller) updateNodeAnnotations(node *corev1.Node, annotations map[st
 	return nil
 }
 
+// daemonStatusMessage returns the TuneD daemon message to report in the Profile
+// status conditions for the TuneD daemon status 'status'.
+func (c *Controller) daemonStatusMessage(status Bits) string {
+	if (status&scSysctlOverride) != 0 && (status&scError) == 0 {
+		// The overridden sysctl is reported.
+		return c.daemon.stderr
+	}
+	if len(c.daemon.messages) > 0 {
+		return c.daemon.messages.String()
+	}
+	return c.daemon.stderr
+}
+
 func (c *Controller) daemonMessage(change Change, message string) string {
 	if len(message) > 0 {
 		return message
@@ -1312,6 +1685,12 @@ func (c *Controller) updateTunedProfile(change Change) (err error) {
 	}
 
 	bootcmdlineAnnotVal, bootcmdlineAnnotSet := node.ObjectMeta.Annotations[tunedv1.TunedBootcmdlineAnnotationKey]
+	if bootcmdlineAnnotSet && bootcmdlineAnnotVal != bootcmdline {
+		// Kernel arguments TuneD no longer calculates need a reboot to be removed too.
+		c.daemon.kernelArgs.previous = bootcmdlineAnnotVal
+	}
+	c.kernelArgumentsCompare(bootcmdline, c.daemon.kernelArgs.previous)
+
 	if !bootcmdlineAnnotSet || bootcmdlineAnnotVal != bootcmdline {
 		annotations := map[string]string{tunedv1.TunedBootcmdlineAnnotationKey: bootcmdline}
 		err = c.updateNodeAnnotations(node, annotations)
@@ -1350,32 +1729,62 @@ func (c *Controller) updateTunedProfileStatus(ctx context.Context, change Change
 			// just log and carry on, we will use this info to clarify status conditions
 			klog.Errorf("%s", err.Error())
 		}
+	} else {
+		message = c.daemonStatusMessage(daemonStatus)
+	}
+
+	var deferredUpdate *tunedv1.ProfileDeferredUpdate
+	if (daemonStatus & scDeferred) != 0 {
+		deferredUpdate = &tunedv1.ProfileDeferredUpdate{
+			Fingerprint:  c.daemon.profileFingerprintUnpacked,
+			TunedProfile: c.daemon.recommendedProfile,
+			Diff:         c.daemon.deferredDiff,
+		}
 	}
 
 	statusConditions := computeStatusConditions(daemonStatus, message, profile.Status.Conditions)
+	statusConditions = computeRolledBackCondition(c.daemon.rollbackOnDegraded, c.daemon.rolledBackFrom != "", c.daemon.rolledBackStderr, statusConditions)
+	statusConditions = computeDriftedCondition(c.daemon.verifyInterval > 0, c.daemon.drifted, statusConditions)
+	statusConditions = computeRebootRequiredCondition(c.daemon.kernelArgs.known, c.daemon.kernelArgs.missing, c.daemon.kernelArgs.extra, statusConditions)
 	klog.V(4).Infof("computed status conditions: %#v", statusConditions)
 	c.daemon.status = daemonStatus
+	daemonStatusMetrics(daemonStatus)
+
+	// Events are only recorded for the status stored in the Profile, so that
+	// a failed status update does not record them repeatedly on retries.
+	events := computeStatusEvents(change.tunedReload, activeProfile, profile.Status.Conditions, statusConditions)
+	recordEvents := func(profile *tunedv1.Profile) {
+		for _, event := range events {
+			ntoclient.RecordProfileEvent(c.recorder, profile, event.eventtype, event.reason, event.message)
+		}
+	}
 
 	if profile.Status.TunedProfile == activeProfile &&
+		profile.Status.Revision == c.daemon.revisionEffective &&
+		reflect.DeepEqual(profile.Status.DeferredUpdate, deferredUpdate) &&
 		conditionsEqual(profile.Status.Conditions, statusConditions) {
 		klog.V(2).Infof("updateTunedProfileStatus(): no need to update status of Profile %s", profile.Name)
+		recordEvents(profile)
 		return nil
 	}
 
 	profile = profile.DeepCopy() // never update the objects from cache
 
 	profile.Status.TunedProfile = activeProfile
+	profile.Status.Revision = c.daemon.revisionEffective
 	profile.Status.Conditions = statusConditions
-	_, err = c.clients.Tuned.TunedV1().Profiles(operandNamespace).UpdateStatus(ctx, profile, metav1.UpdateOptions{})
+	profile.Status.DeferredUpdate = deferredUpdate
+	updated, err := c.clients.Tuned.TunedV1().Profiles(operandNamespace).UpdateStatus(ctx, profile, metav1.UpdateOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to update Profile %s status: %v", profile.Name, err)
 	}
+	recordEvents(updated)
 	return nil
 }
 
 // storeDeferredUpdate sets the node state (on storage, like disk) to signal
 // there is a deferred update pending.
-func (c *Controller) storeDeferredUpdate(deferredFP string) (derr error) {
+func (c *Controller) storeDeferredUpdate(deferredFP string) error {
 	// "overwriting" is fine, because we only want an empty data file.
 	// all the races are benign, so we go for the simplest approach
 	fp, err := os.Create(tunedDeferredUpdateEphemeralFilePath)
@@ -1384,11 +1793,16 @@ func (c *Controller) storeDeferredUpdate(deferredFP string) (derr error) {
 	}
 	_ = fp.Close() // unlikely to fail, we don't write anything
 
+	return fileWriteAtomic(tunedDeferredUpdatePersistentFilePath, []byte(deferredFP))
+}
+
+// fileWriteAtomic writes 'data' to file 'name' atomically.
+func fileWriteAtomic(name string, data []byte) (derr error) {
 	// overwriting files is racy, and output can be mixed in.
 	// the safest approach is to create a temporary file, write
 	// the full content to it and then rename it, because rename(2)
 	// is atomic, this is guaranteed safe and race-free.
-	dst, err := os.CreateTemp(ocpTunedHome, "ocpdeferred")
+	dst, err := os.CreateTemp(filepath.Dir(name), "ocptuned")
 	if err != nil {
 		return err
 	}
@@ -1400,14 +1814,138 @@ func (c *Controller) storeDeferredUpdate(deferredFP string) (derr error) {
 		derr = dst.Close()
 		os.Remove(dst.Name()) // avoid littering with tmp files
 	}()
-	if _, err := dst.WriteString(deferredFP); err != nil {
+	if _, err := dst.Write(data); err != nil {
 		return err
 	}
 	if err := dst.Close(); err != nil {
 		return err
 	}
 	dst = nil // avoid double close()s, the second will fail
-	return os.Rename(tmpName, tunedDeferredUpdatePersistentFilePath)
+	return os.Rename(tmpName, name)
+}
+
+// rollbackState is the last good TuneD profile set and the rollback state
+// stored on disk.
+type rollbackState struct {
+	Profiles           []tunedv1.TunedProfile `json:"profiles"`
+	RecommendedProfile string                 `json:"recommendedProfile"`
+	Fingerprint        string                 `json:"fingerprint"`
+	Revision           string                 `json:"revision,omitempty"`
+	RolledBackFrom     string                 `json:"rolledBackFrom,omitempty"`
+	RolledBackStderr   string                 `json:"rolledBackStderr,omitempty"`
+}
+
+// storeRollbackState stores the last good TuneD profile set and the rollback
+// state on disk, so that they survive operand restarts.
+func (c *Controller) storeRollbackState() error {
+	data, err := json.Marshal(rollbackState{
+		Profiles:           c.daemon.good.profiles,
+		RecommendedProfile: c.daemon.good.recommendedProfile,
+		Fingerprint:        c.daemon.good.fingerprint,
+		Revision:           c.daemon.good.revision,
+		RolledBackFrom:     c.daemon.rolledBackFrom,
+		RolledBackStderr:   c.daemon.rolledBackStderr,
+	})
+	if err != nil {
+		return err
+	}
+	return fileWriteAtomic(tunedRollbackStateFilePath, data)
+}
+
+// recoverRollbackState restores the last good TuneD profile set and the rollback
+// state stored on disk by storeRollbackState.
+func (c *Controller) recoverRollbackState() error {
+	var state rollbackState
+
+	data, err := os.ReadFile(tunedRollbackStateFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", tunedRollbackStateFilePath, err)
+	}
+	if profilesFingerprint(state.Profiles, state.RecommendedProfile) != state.Fingerprint {
+		return fmt.Errorf("fingerprint mismatch of the last good TuneD profile %q", state.RecommendedProfile)
+	}
+
+	c.daemon.good.profiles = state.Profiles
+	c.daemon.good.recommendedProfile = state.RecommendedProfile
+	c.daemon.good.fingerprint = state.Fingerprint
+	c.daemon.good.revision = state.Revision
+	c.daemon.rolledBackFrom = state.RolledBackFrom
+	c.daemon.rolledBackStderr = state.RolledBackStderr
+
+	return nil
+}
+
+// discardDeferredUpdate restores the effective TuneD profile set on disk and clears
+// the node state signalling there is a deferred update pending.  The fingerprint
+// 'discardedFP' of the discarded deferred update is stored on disk, so that the
+// update is not applied after operand or node restarts either.
+func (c *Controller) discardDeferredUpdate(discardedFP string) error {
+	if err := fileWriteAtomic(tunedDiscardedDeferredFilePath, []byte(discardedFP)); err != nil {
+		return err
+	}
+	c.daemon.discardedDeferred = discardedFP
+
+	if c.daemon.profileFingerprintUnpacked != c.daemon.effective.fingerprint {
+		klog.Infof("discarding deferred update %q, keeping TuneD profile %q", c.daemon.profileFingerprintUnpacked, c.daemon.effective.recommendedProfile)
+		if _, _, err := profilesSync(c.daemon.effective.profiles, c.daemon.effective.recommendedProfile); err != nil {
+			return err
+		}
+		if err := TunedRecommendFileWrite(c.daemon.effective.recommendedProfile); err != nil {
+			return err
+		}
+		c.daemon.recommendedProfile = c.daemon.effective.recommendedProfile
+		c.daemon.profileFingerprintUnpacked = c.daemon.effective.fingerprint
+		c.daemon.revisionUnpacked = c.daemon.effective.revision
+	}
+	c.daemon.deferredDiff = nil
+	c.daemon.status &= ^scDeferred
+
+	return removeDeferredUpdate()
+}
+
+// forgetDiscardedDeferredUpdate clears the node state keeping the discarded deferred
+// update from being applied.
+func (c *Controller) forgetDiscardedDeferredUpdate() error {
+	if c.daemon.discardedDeferred == "" {
+		return nil
+	}
+	klog.Infof("discarded deferred update %q superseded", c.daemon.discardedDeferred)
+	if err := os.Remove(tunedDiscardedDeferredFilePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %v", tunedDiscardedDeferredFilePath, err)
+	}
+	c.daemon.discardedDeferred = ""
+	return nil
+}
+
+// recoverDiscardedDeferredUpdate restores the fingerprint of the discarded deferred
+// update stored on disk by discardDeferredUpdate.
+func (c *Controller) recoverDiscardedDeferredUpdate() error {
+	discardedFP, err := os.ReadFile(tunedDiscardedDeferredFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	c.daemon.discardedDeferred = strings.TrimSpace(string(discardedFP))
+	return nil
+}
+
+// removeDeferredUpdate clears the node state (on storage, like disk) signalling
+// there is a deferred update pending.
+func removeDeferredUpdate() error {
+	for _, path := range []string{tunedDeferredUpdatePersistentFilePath, tunedDeferredUpdateEphemeralFilePath} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove %s: %v", path, err)
+		}
+	}
+	return nil
 }
 
 // recoverAndClearDeferredUpdate detects the presence and removes the persistent
@@ -1567,6 +2105,8 @@ func (c *Controller) changeWatcher() (err error) {
 		klog.Infof("monitoring filesystem events on %q", element)
 	}
 
+	defer c.verifyReset(0)
+
 	klog.Info("started controller")
 	for {
 		select {
@@ -1575,6 +2115,11 @@ func (c *Controller) changeWatcher() (err error) {
 
 			return nil
 
+		case <-c.verifyTickerC():
+			klog.V(2).Infof("verifyTicker")
+			// Notify the event processor that the active profile needs verification.
+			c.wqTuneD.Add(wqKeyTuned{kind: wqKindDaemon, change: Change{profileStatus: true, verify: true}})
+
 		case fsEvent := <-wFs.Events:
 			klog.V(2).Infof("fsEvent")
 			if fsEvent.Op&fsnotify.Write == fsnotify.Write {
@@ -1704,6 +2249,8 @@ func RunInCluster(stopCh <-chan struct{}, version string) error {
 		panic(err.Error())
 	}
 
+	go c.metricsServerRun()
+
 	profiles, recommended, err := profilesRepackPath(tunedRecommendFile, tunedProfilesDirCustom)
 	if err != nil {
 		// keep going, immediate updates are expected to work as usual
@@ -1727,6 +2274,18 @@ func RunInCluster(stopCh <-chan struct{}, version string) error {
 	c.daemon.recoveredRecommendedProfile = recommended
 	klog.Infof("starting: profile unpacked is %q fingerprint %q", recommended, profileFP)
 
+	if err := c.recoverRollbackState(); err != nil {
+		klog.Errorf("unable to recover the last good TuneD profile: %v", err)
+	} else if c.daemon.good.fingerprint != "" {
+		klog.Infof("starting: last good TuneD profile %q fingerprint %q", c.daemon.good.recommendedProfile, c.daemon.good.fingerprint)
+	}
+
+	if err := c.recoverDiscardedDeferredUpdate(); err != nil {
+		klog.Errorf("unable to recover the discarded deferred update: %v", err)
+	} else if c.daemon.discardedDeferred != "" {
+		klog.Infof("starting: discarded deferred update %q", c.daemon.discardedDeferred)
+	}
+
 	deferredFP, isNodeReboot, err := c.recoverAndClearDeferredUpdate()
 	if err != nil {
 		klog.ErrorS(err, "unable to recover the pending update")
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/util"
@@ -269,11 +271,21 @@ func TestChangeString(t *testing.T) {
 			},
 			expected: "tuned.Change{profileStatus:true}",
 		},
+		{
+			name: "deferWindow",
+			change: Change{
+				deferredMode: testDeferMode,
+				deferWindow:  testDeferWindow,
+			},
+			expected: `tuned.Change{deferredMode:"window 0 2 * * 6 2h", deferWindow:"0 2 * * 6 2h0m0s"}`,
+		},
 		// check all the fields are represented. Keep me last
 		{
-			name:     "full",
-			change:   fullChange(),
-			expected: fmt.Sprintf("%#v", fullChange()),
+			name:   "full",
+			change: fullChange(),
+			// The maintenance window is represented by its schedule and duration.
+			expected: strings.Replace(fmt.Sprintf("%#v", fullChange()),
+				fmt.Sprintf("(%T)(%p)", testDeferWindow, testDeferWindow), fmt.Sprintf("%q", testDeferWindow.String()), 1),
 		},
 	}
 	for _, tt := range testCases {
@@ -286,17 +298,26 @@ func TestChangeString(t *testing.T) {
 	}
 }
 
+const testDeferMode = util.DeferMode("window 0 2 * * 6 2h")
+
+var testDeferWindow, _ = util.ParseDeferWindow(testDeferMode)
+
 func fullChange() Change {
 	return Change{
 		profile:            true,
 		profileStatus:      true,
 		tunedReload:        true,
 		nodeRestart:        true,
+		verify:             true,
 		debug:              true,
 		provider:           "test-provider",
 		reapplySysctl:      true,
+		rollbackOnDegraded: true,
+		verifyInterval:     time.Minute,
 		recommendedProfile: "test-profile",
-		deferredMode:       util.DeferAlways,
+		deferredMode:       testDeferMode,
+		deferWindow:        testDeferWindow,
+		discardDeferred:    "test-fingerprint",
 		message:            "test-message",
 	}
 }
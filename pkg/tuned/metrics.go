@@ -0,0 +1,89 @@
+package tuned
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/cluster-node-tuning-operator/pkg/metrics"
+)
+
+// metricsCAPollInterval is the interval of checking the root certificate bundle
+// used to verify the metrics server client certificates for changes.
+const metricsCAPollInterval = 5 * time.Minute
+
+// daemonStatusNames maps the TuneD daemon status bits to their names used in metrics.
+var daemonStatusNames = map[Bits]string{
+	scApplied:        "applied",
+	scWarn:           "warn",
+	scError:          "error",
+	scSysctlOverride: "sysctl_override",
+	scReloading:      "reloading",
+	scDeferred:       "deferred",
+	scUnknown:        "unknown",
+}
+
+// daemonStatusBits maps the names of all TuneD daemon status bits to whether
+// they are set in 'status'.
+func daemonStatusBits(status Bits) map[string]bool {
+	bits := make(map[string]bool, len(daemonStatusNames))
+	for bit, name := range daemonStatusNames {
+		bits[name] = (status & bit) != 0
+	}
+	return bits
+}
+
+// daemonStatusMetrics exposes the TuneD daemon status bits 'status' in metrics.
+func daemonStatusMetrics(status Bits) {
+	metrics.TunedStatus(daemonStatusBits(status))
+	metrics.TunedDeferredUpdatePending((status & scDeferred) != 0)
+}
+
+// metricsCABundle returns the root certificate bundle used to verify the metrics
+// server client certificates.
+func (c *Controller) metricsCABundle() (string, error) {
+	cm, err := c.kubeclient.CoreV1().ConfigMaps(metrics.AuthConfigMapNamespace).Get(context.TODO(), metrics.AuthConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get ConfigMap %s/%s: %v", metrics.AuthConfigMapNamespace, metrics.AuthConfigMapName, err)
+	}
+
+	ca, ok := cm.Data[metrics.AuthConfigMapClientCAKey]
+	if !ok {
+		return "", fmt.Errorf("failed to find key %s in ConfigMap %s/%s", metrics.AuthConfigMapClientCAKey, metrics.AuthConfigMapNamespace, metrics.AuthConfigMapName)
+	}
+
+	return ca, nil
+}
+
+// metricsServerRun runs the operand metrics server until the controller stops.
+// The server is started once the root certificate bundle for client authentication
+// is available and restarted whenever the bundle changes.
+func (c *Controller) metricsServerRun() {
+	var caBundle string
+
+	ctx := wait.ContextForChannel(c.stopCh)
+	server := metrics.NewOperandServer()
+
+	go func() {
+		_ = wait.PollUntilContextCancel(ctx, metricsCAPollInterval, true, func(ctx context.Context) (bool, error) {
+			ca, err := c.metricsCABundle()
+			if err != nil {
+				klog.Errorf("failed to get root certificate bundle of the metrics server: %v", err)
+				return false, nil
+			}
+			if ca != caBundle {
+				caBundle = ca
+				server.UpdateCA(ctx, ca)
+			}
+			return false, nil
+		})
+	}()
+
+	if err := server.RunOperand(ctx); err != nil {
+		klog.Errorf("error from metrics server: %v", err)
+	}
+}
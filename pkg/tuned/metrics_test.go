@@ -0,0 +1,66 @@
+package tuned
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDaemonStatusBits(t *testing.T) {
+	none := map[string]bool{
+		"applied":         false,
+		"warn":            false,
+		"error":           false,
+		"sysctl_override": false,
+		"reloading":       false,
+		"deferred":        false,
+		"unknown":         false,
+	}
+	with := func(names ...string) map[string]bool {
+		bits := map[string]bool{}
+		for name, set := range none {
+			bits[name] = set
+		}
+		for _, name := range names {
+			bits[name] = true
+		}
+		return bits
+	}
+
+	tests := []struct {
+		status   Bits
+		expected map[string]bool
+	}{
+		{
+			status:   0,
+			expected: none,
+		},
+		{
+			status:   scApplied,
+			expected: with("applied"),
+		},
+		{
+			status:   scApplied | scWarn | scSysctlOverride,
+			expected: with("applied", "warn", "sysctl_override"),
+		},
+		{
+			status:   scError | scReloading,
+			expected: with("error", "reloading"),
+		},
+		{
+			status:   scDeferred | scUnknown,
+			expected: with("deferred", "unknown"),
+		},
+		{
+			// Bits without a metric are ignored.
+			status:   scApplied | scUnknown<<1,
+			expected: with("applied"),
+		},
+	}
+
+	for i, tc := range tests {
+		have := daemonStatusBits(tc.status)
+		if !reflect.DeepEqual(have, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %v\n\thave: %v", i+1, tc.expected, have)
+		}
+	}
+}
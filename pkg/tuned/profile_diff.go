@@ -0,0 +1,124 @@
+package tuned
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/ini.v1"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+// maxProfilesDiffItems is the maximum number of differences between TuneD profile
+// sets reported in the Profile status.
+const maxProfilesDiffItems = 20
+
+// profilesDiff returns a summary of the differences between the effective TuneD
+// profile set 'effective' recommending 'effectiveRecommended' and the pending TuneD
+// profile set 'pending' recommending 'pendingRecommended'.
+func profilesDiff(effective []tunedv1.TunedProfile, effectiveRecommended string, pending []tunedv1.TunedProfile, pendingRecommended string) []string {
+	var diff []string
+
+	if effectiveRecommended != pendingRecommended {
+		diff = append(diff, fmt.Sprintf("recommended profile %q -> %q", effectiveRecommended, pendingRecommended))
+	}
+
+	effectiveData := profilesData(effective)
+	pendingData := profilesData(pending)
+	for _, name := range sortedKeys(effectiveData, pendingData) {
+		e, inEffective := effectiveData[name]
+		p, inPending := pendingData[name]
+		switch {
+		case !inEffective:
+			diff = append(diff, fmt.Sprintf("profile %q added", name))
+		case !inPending:
+			diff = append(diff, fmt.Sprintf("profile %q removed", name))
+		case e != p:
+			diff = append(diff, profileDiff(name, e, p)...)
+		}
+	}
+
+	if len(diff) > maxProfilesDiffItems {
+		more := len(diff) - maxProfilesDiffItems
+		diff = append(diff[:maxProfilesDiffItems], fmt.Sprintf("and %d more", more))
+	}
+
+	return diff
+}
+
+// profileDiff returns the differences in the options of TuneD profile 'name'
+// with data 'effective' and 'pending'.
+func profileDiff(name, effective, pending string) []string {
+	effectiveCfg, errEffective := ini.Load([]byte(effective))
+	pendingCfg, errPending := ini.Load([]byte(pending))
+	if errEffective != nil || errPending != nil {
+		return []string{fmt.Sprintf("profile %q changed", name)}
+	}
+
+	var (
+		diff              []string
+		effectiveSections = iniSections(effectiveCfg)
+		pendingSections   = iniSections(pendingCfg)
+	)
+	for _, section := range sortedKeys(effectiveSections, pendingSections) {
+		e := effectiveSections[section]
+		p := pendingSections[section]
+		for _, key := range sortedKeys(e, p) {
+			ev, inEffective := e[key]
+			pv, inPending := p[key]
+			switch {
+			case !inEffective:
+				diff = append(diff, fmt.Sprintf("profile %q: [%s] +%s=%s", name, section, key, pv))
+			case !inPending:
+				diff = append(diff, fmt.Sprintf("profile %q: [%s] -%s", name, section, key))
+			case ev != pv:
+				diff = append(diff, fmt.Sprintf("profile %q: [%s] %s=%s -> %s", name, section, key, ev, pv))
+			}
+		}
+	}
+	if len(diff) == 0 {
+		// The profile data differ only in comments or formatting.
+		return []string{fmt.Sprintf("profile %q changed", name)}
+	}
+
+	return diff
+}
+
+// profilesData maps the names of TuneD profiles 'profiles' to their data.
+func profilesData(profiles []tunedv1.TunedProfile) map[string]string {
+	data := make(map[string]string, len(profiles))
+	for _, profile := range profiles {
+		if profile.Name == nil || profile.Data == nil {
+			continue
+		}
+		data[*profile.Name] = *profile.Data
+	}
+	return data
+}
+
+// iniSections maps the names of the non-empty sections of INI data 'cfg' to their keys and values.
+func iniSections(cfg *ini.File) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	for _, section := range cfg.Sections() {
+		if len(section.Keys()) == 0 {
+			continue
+		}
+		sections[section.Name()] = section.KeysHash()
+	}
+	return sections
+}
+
+// sortedKeys returns the sorted union of the keys of maps 'a' and 'b'.
+func sortedKeys[V any](a, b map[string]V) []string {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,86 @@
+package tuned
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+)
+
+func TestProfilesDiff(t *testing.T) {
+	profile := func(name, data string) tunedv1.TunedProfile {
+		return tunedv1.TunedProfile{Name: newString(name), Data: newString(data)}
+	}
+
+	testCases := []struct {
+		name                 string
+		effective            []tunedv1.TunedProfile
+		effectiveRecommended string
+		pending              []tunedv1.TunedProfile
+		pendingRecommended   string
+		expected             []string
+	}{
+		{
+			name:                 "no-change",
+			effective:            []tunedv1.TunedProfile{profile("a", "[sysctl]\nvm.swappiness=10\n")},
+			effectiveRecommended: "a",
+			pending:              []tunedv1.TunedProfile{profile("a", "[sysctl]\nvm.swappiness=10\n")},
+			pendingRecommended:   "a",
+			expected:             nil,
+		},
+		{
+			name:                 "switch",
+			effective:            []tunedv1.TunedProfile{profile("a", "[main]\n")},
+			effectiveRecommended: "a",
+			pending:              []tunedv1.TunedProfile{profile("b", "[main]\n")},
+			pendingRecommended:   "b",
+			expected: []string{
+				`recommended profile "a" -> "b"`,
+				`profile "a" removed`,
+				`profile "b" added`,
+			},
+		},
+		{
+			name:                 "options",
+			effective:            []tunedv1.TunedProfile{profile("a", "[main]\ninclude=openshift-node\n[sysctl]\nvm.swappiness=10\nkernel.pid_max=4194304\n")},
+			effectiveRecommended: "a",
+			pending:              []tunedv1.TunedProfile{profile("a", "[main]\ninclude=openshift-node\n[sysctl]\nvm.swappiness=20\nvm.dirty_ratio=10\n[vm]\ntransparent_hugepages=never\n")},
+			pendingRecommended:   "a",
+			expected: []string{
+				`profile "a": [sysctl] -kernel.pid_max`,
+				`profile "a": [sysctl] +vm.dirty_ratio=10`,
+				`profile "a": [sysctl] vm.swappiness=10 -> 20`,
+				`profile "a": [vm] +transparent_hugepages=never`,
+			},
+		},
+		{
+			name:                 "comments",
+			effective:            []tunedv1.TunedProfile{profile("a", "[sysctl]\nvm.swappiness=10\n")},
+			effectiveRecommended: "a",
+			pending:              []tunedv1.TunedProfile{profile("a", "# comment\n[sysctl]\nvm.swappiness=10\n")},
+			pendingRecommended:   "a",
+			expected:             []string{`profile "a" changed`},
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := profilesDiff(tt.effective, tt.effectiveRecommended, tt.pending, tt.pendingRecommended)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got=%#v expected=%#v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestProfilesDiffTruncated(t *testing.T) {
+	var pending []tunedv1.TunedProfile
+	for i := 0; i < maxProfilesDiffItems+5; i++ {
+		pending = append(pending, tunedv1.TunedProfile{Name: newString(fmt.Sprintf("p%02d", i)), Data: newString("[main]\n")})
+	}
+
+	got := profilesDiff(nil, "", pending, "")
+	if len(got) != maxProfilesDiffItems+1 || got[maxProfilesDiffItems] != "and 5 more" {
+		t.Errorf("got=%#v expected %d items and \"and 5 more\"", got, maxProfilesDiffItems)
+	}
+}
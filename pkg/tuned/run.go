@@ -150,21 +150,20 @@ func TunedRun(cmd *exec.Cmd, daemon *Daemon, onDaemonReload func()) error {
 				daemon.status |= scApplied
 			}
 
-			strIndex := strings.Index(l, " WARNING ")
-			if strIndex >= 0 {
-				daemon.status |= scWarn
-				prevError := ((daemon.status & scError) != 0)
-				if !prevError { // don't overwrite an error message
-					daemon.stderr = l[strIndex:] // trim timestamp from log
+			if msg, ok := parseTunedLogLine(l); ok {
+				daemon.messages = daemon.messages.add(msg)
+				if msg.isError() {
+					daemon.status |= scError
+					daemon.stderr = msg.text
+				} else {
+					daemon.status |= scWarn
+					prevError := ((daemon.status & scError) != 0)
+					if !prevError { // don't overwrite an error message
+						daemon.stderr = msg.text
+					}
 				}
 			}
 
-			strIndex = strings.Index(l, " ERROR ")
-			if strIndex >= 0 {
-				daemon.status |= scError
-				daemon.stderr = l[strIndex:] // trim timestamp from log
-			}
-
 			sysctl := overridenSysctl(l)
 			if sysctl != "" {
 				daemon.status |= scSysctlOverride
@@ -187,6 +186,7 @@ func TunedRun(cmd *exec.Cmd, daemon *Daemon, onDaemonReload func()) error {
 	daemon.status = 0
 	daemon.status |= scReloading
 	daemon.stderr = ""
+	daemon.messages = nil
 	if err = cmd.Start(); err != nil {
 		return fmt.Errorf("error starting tuned: %w", err)
 	}
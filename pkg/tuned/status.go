@@ -1,6 +1,9 @@
 package tuned
 
 import (
+	"fmt"
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -36,6 +39,20 @@ func setStatusCondition(oldConditions []tunedv1.ProfileStatusCondition, conditio
 	return newConditions
 }
 
+// removeStatusCondition returns the given slice of conditions without the
+// condition of type 'conditionType'.
+func removeStatusCondition(oldConditions []tunedv1.ProfileStatusCondition, conditionType tunedv1.ProfileConditionType) []tunedv1.ProfileStatusCondition {
+	newConditions := []tunedv1.ProfileStatusCondition{}
+
+	for _, c := range oldConditions {
+		if c.Type != conditionType {
+			newConditions = append(newConditions, c)
+		}
+	}
+
+	return newConditions
+}
+
 // conditionsEqual returns true if and only if the provided slices of conditions
 // (ignoring LastTransitionTime) are equal.
 func conditionsEqual(oldConditions, newConditions []tunedv1.ProfileStatusCondition) bool {
@@ -147,3 +164,149 @@ func computeStatusConditions(status Bits, message string, conditions []tunedv1.P
 
 	return conditions
 }
+
+// computeRolledBackCondition takes the old conditions 'conditions' and returns
+// them with the RolledBack condition set based on whether the rollback on TuneD
+// daemon errors is 'enabled', whether it happened ('rolledBack') and the TuneD
+// daemon error 'message' which caused it.  The RolledBack condition is removed
+// when the rollback is not enabled.
+func computeRolledBackCondition(enabled, rolledBack bool, message string, conditions []tunedv1.ProfileStatusCondition) []tunedv1.ProfileStatusCondition {
+	if !enabled {
+		return removeStatusCondition(conditions, tunedv1.TunedRolledBack)
+	}
+
+	tunedRolledBackCondition := tunedv1.ProfileStatusCondition{
+		Type: tunedv1.TunedRolledBack,
+	}
+
+	if rolledBack {
+		tunedRolledBackCondition.Status = corev1.ConditionTrue
+		tunedRolledBackCondition.Reason = "TunedError"
+		tunedRolledBackCondition.Message = "Reverted to the last TuneD daemon profile which applied cleanly. TuneD stderr: " + message
+	} else {
+		tunedRolledBackCondition.Status = corev1.ConditionFalse
+		tunedRolledBackCondition.Reason = "AsExpected"
+		tunedRolledBackCondition.Message = "No rollback of the TuneD daemon profile."
+	}
+
+	return setStatusCondition(conditions, &tunedRolledBackCondition)
+}
+
+// computeDriftedCondition takes the old conditions 'conditions' and returns them
+// with the Drifted condition set based on whether the verification of the active
+// profile is 'enabled' and the settings which 'drifted'.  The Drifted condition is
+// removed when the verification is not enabled.
+func computeDriftedCondition(enabled bool, drifted []string, conditions []tunedv1.ProfileStatusCondition) []tunedv1.ProfileStatusCondition {
+	if !enabled {
+		return removeStatusCondition(conditions, tunedv1.TunedDrifted)
+	}
+
+	tunedDriftedCondition := tunedv1.ProfileStatusCondition{
+		Type: tunedv1.TunedDrifted,
+	}
+
+	if len(drifted) > 0 {
+		tunedDriftedCondition.Status = corev1.ConditionTrue
+		tunedDriftedCondition.Reason = "SettingsChanged"
+		tunedDriftedCondition.Message = "Settings of the TuneD daemon profile no longer match the node state: " + driftedMessage(drifted)
+	} else {
+		tunedDriftedCondition.Status = corev1.ConditionFalse
+		tunedDriftedCondition.Reason = "AsExpected"
+		tunedDriftedCondition.Message = "Settings of the TuneD daemon profile match the node state."
+	}
+
+	return setStatusCondition(conditions, &tunedDriftedCondition)
+}
+
+// computeRebootRequiredCondition takes the old conditions 'conditions' and returns
+// them with the RebootRequired condition set based on the kernel arguments 'missing'
+// from and 'extra' on the running kernel command-line.  The RebootRequired condition
+// is removed when the running kernel command-line is not 'known'.
+func computeRebootRequiredCondition(known bool, missing, extra []string, conditions []tunedv1.ProfileStatusCondition) []tunedv1.ProfileStatusCondition {
+	if !known {
+		return removeStatusCondition(conditions, tunedv1.TunedRebootRequired)
+	}
+
+	tunedRebootRequiredCondition := tunedv1.ProfileStatusCondition{
+		Type: tunedv1.TunedRebootRequired,
+	}
+
+	if len(missing) > 0 || len(extra) > 0 {
+		var items []string
+		if len(missing) > 0 {
+			items = append(items, "missing: "+strings.Join(missing, " "))
+		}
+		if len(extra) > 0 {
+			items = append(items, "extra: "+strings.Join(extra, " "))
+		}
+		tunedRebootRequiredCondition.Status = corev1.ConditionTrue
+		tunedRebootRequiredCondition.Reason = "KernelArgumentsMismatch"
+		tunedRebootRequiredCondition.Message = "The running kernel command-line does not match the TuneD daemon profile; " + strings.Join(items, "; ")
+	} else {
+		tunedRebootRequiredCondition.Status = corev1.ConditionFalse
+		tunedRebootRequiredCondition.Reason = "AsExpected"
+		tunedRebootRequiredCondition.Message = "The running kernel command-line matches the TuneD daemon profile."
+	}
+
+	return setStatusCondition(conditions, &tunedRebootRequiredCondition)
+}
+
+// statusEvent is an Event to record on a Profile status change.
+type statusEvent struct {
+	eventtype string
+	reason    string
+	message   string
+}
+
+// findStatusCondition returns the condition of type 'conditionType' from
+// 'conditions' or nil if not found.
+func findStatusCondition(conditions []tunedv1.ProfileStatusCondition, conditionType tunedv1.ProfileConditionType) *tunedv1.ProfileStatusCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// computeStatusEvents returns the Events to record when the Profile status conditions
+// change from 'oldConditions' to 'newConditions'.  'reloaded' is true right after
+// the TuneD daemon (re)applied the TuneD profile 'activeProfile'.
+func computeStatusEvents(reloaded bool, activeProfile string, oldConditions, newConditions []tunedv1.ProfileStatusCondition) []statusEvent {
+	var events []statusEvent
+
+	changed := func(condition *tunedv1.ProfileStatusCondition) bool {
+		old := findStatusCondition(oldConditions, condition.Type)
+		return old == nil || old.Status != condition.Status || old.Reason != condition.Reason
+	}
+
+	if applied := findStatusCondition(newConditions, tunedv1.TunedProfileApplied); applied != nil {
+		if applied.Status == corev1.ConditionTrue && (reloaded || changed(applied)) {
+			events = append(events, statusEvent{
+				eventtype: corev1.EventTypeNormal,
+				reason:    tunedv1.EventReasonProfileApplied,
+				message:   fmt.Sprintf("TuneD profile %s applied.", activeProfile),
+			})
+		}
+		if applied.Reason == "Deferred" && changed(applied) {
+			events = append(events, statusEvent{
+				eventtype: corev1.EventTypeNormal,
+				reason:    tunedv1.EventReasonDeferredUpdatePending,
+				message:   applied.Message,
+			})
+		}
+	}
+
+	if degraded := findStatusCondition(newConditions, tunedv1.TunedDegraded); degraded != nil {
+		// Deferred updates are reported as Degraded, but they are not failures.
+		if degraded.Status == corev1.ConditionTrue && degraded.Reason != "TunedDeferredUpdate" && (reloaded || changed(degraded)) {
+			events = append(events, statusEvent{
+				eventtype: corev1.EventTypeWarning,
+				reason:    tunedv1.EventReasonProfileDegraded,
+				message:   degraded.Message,
+			})
+		}
+	}
+
+	return events
+}
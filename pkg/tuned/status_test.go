@@ -167,3 +167,282 @@ func clearTimestamps(conds []tunedv1.ProfileStatusCondition) []tunedv1.ProfileSt
 func testTime() time.Time {
 	return time.Date(1980, time.January, 1, 0, 0, 0, 0, time.UTC)
 }
+
+func TestComputeRolledBackCondition(t *testing.T) {
+	degraded := tunedv1.ProfileStatusCondition{
+		Type:   tunedv1.TunedDegraded,
+		Status: corev1.ConditionFalse,
+		LastTransitionTime: metav1.Time{
+			Time: testTime(),
+		},
+		Reason: "AsExpected",
+	}
+	rolledBack := tunedv1.ProfileStatusCondition{
+		Type:   tunedv1.TunedRolledBack,
+		Status: corev1.ConditionTrue,
+		LastTransitionTime: metav1.Time{
+			Time: testTime(),
+		},
+		Reason:  "TunedError",
+		Message: "Reverted to the last TuneD daemon profile which applied cleanly. TuneD stderr: ERROR    tuned.plugins.base: instance sysctl: failed",
+	}
+
+	testCases := []struct {
+		name       string
+		enabled    bool
+		rolledBack bool
+		stderr     string
+		conds      []tunedv1.ProfileStatusCondition
+		expected   []tunedv1.ProfileStatusCondition
+	}{
+		{
+			name:     "disabled",
+			conds:    []tunedv1.ProfileStatusCondition{degraded, rolledBack},
+			expected: []tunedv1.ProfileStatusCondition{degraded},
+		},
+		{
+			name:    "enabled-no-rollback",
+			enabled: true,
+			conds:   []tunedv1.ProfileStatusCondition{degraded},
+			expected: []tunedv1.ProfileStatusCondition{
+				degraded,
+				{
+					Type:   tunedv1.TunedRolledBack,
+					Status: corev1.ConditionFalse,
+					LastTransitionTime: metav1.Time{
+						Time: testTime(),
+					},
+					Reason:  "AsExpected",
+					Message: "No rollback of the TuneD daemon profile.",
+				},
+			},
+		},
+		{
+			name:       "rolled-back",
+			enabled:    true,
+			rolledBack: true,
+			stderr:     "ERROR    tuned.plugins.base: instance sysctl: failed",
+			conds:      []tunedv1.ProfileStatusCondition{degraded},
+			expected:   []tunedv1.ProfileStatusCondition{degraded, rolledBack},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := clearTimestamps(computeRolledBackCondition(tt.enabled, tt.rolledBack, tt.stderr, tt.conds))
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got=%#v expected=%#v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComputeDriftedCondition(t *testing.T) {
+	drifted := tunedv1.ProfileStatusCondition{
+		Type:   tunedv1.TunedDrifted,
+		Status: corev1.ConditionTrue,
+		LastTransitionTime: metav1.Time{
+			Time: testTime(),
+		},
+		Reason:  "SettingsChanged",
+		Message: "Settings of the TuneD daemon profile no longer match the node state: sysctl vm.swappiness=10 (have 60)",
+	}
+	notDrifted := tunedv1.ProfileStatusCondition{
+		Type:   tunedv1.TunedDrifted,
+		Status: corev1.ConditionFalse,
+		LastTransitionTime: metav1.Time{
+			Time: testTime(),
+		},
+		Reason:  "AsExpected",
+		Message: "Settings of the TuneD daemon profile match the node state.",
+	}
+
+	testCases := []struct {
+		name     string
+		enabled  bool
+		drifted  []string
+		conds    []tunedv1.ProfileStatusCondition
+		expected []tunedv1.ProfileStatusCondition
+	}{
+		{
+			name:     "disabled",
+			conds:    []tunedv1.ProfileStatusCondition{drifted},
+			expected: []tunedv1.ProfileStatusCondition{},
+		},
+		{
+			name:     "no-drift",
+			enabled:  true,
+			conds:    []tunedv1.ProfileStatusCondition{drifted},
+			expected: []tunedv1.ProfileStatusCondition{notDrifted},
+		},
+		{
+			name:     "drift",
+			enabled:  true,
+			drifted:  []string{"sysctl vm.swappiness=10 (have 60)"},
+			conds:    []tunedv1.ProfileStatusCondition{notDrifted},
+			expected: []tunedv1.ProfileStatusCondition{drifted},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := clearTimestamps(computeDriftedCondition(tt.enabled, tt.drifted, tt.conds))
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got=%#v expected=%#v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComputeRebootRequiredCondition(t *testing.T) {
+	rebootRequired := tunedv1.ProfileStatusCondition{
+		Type:   tunedv1.TunedRebootRequired,
+		Status: corev1.ConditionTrue,
+		LastTransitionTime: metav1.Time{
+			Time: testTime(),
+		},
+		Reason:  "KernelArgumentsMismatch",
+		Message: "The running kernel command-line does not match the TuneD daemon profile; missing: nohz_full=1-3 skew_tick=1; extra: nohz_full=1-2",
+	}
+	noRebootRequired := tunedv1.ProfileStatusCondition{
+		Type:   tunedv1.TunedRebootRequired,
+		Status: corev1.ConditionFalse,
+		LastTransitionTime: metav1.Time{
+			Time: testTime(),
+		},
+		Reason:  "AsExpected",
+		Message: "The running kernel command-line matches the TuneD daemon profile.",
+	}
+
+	testCases := []struct {
+		name     string
+		known    bool
+		missing  []string
+		extra    []string
+		conds    []tunedv1.ProfileStatusCondition
+		expected []tunedv1.ProfileStatusCondition
+	}{
+		{
+			name:     "unknown",
+			conds:    []tunedv1.ProfileStatusCondition{rebootRequired},
+			expected: []tunedv1.ProfileStatusCondition{},
+		},
+		{
+			name:     "match",
+			known:    true,
+			conds:    []tunedv1.ProfileStatusCondition{rebootRequired},
+			expected: []tunedv1.ProfileStatusCondition{noRebootRequired},
+		},
+		{
+			name:     "mismatch",
+			known:    true,
+			missing:  []string{"nohz_full=1-3", "skew_tick=1"},
+			extra:    []string{"nohz_full=1-2"},
+			conds:    []tunedv1.ProfileStatusCondition{noRebootRequired},
+			expected: []tunedv1.ProfileStatusCondition{rebootRequired},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := clearTimestamps(computeRebootRequiredCondition(tt.known, tt.missing, tt.extra, tt.conds))
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got=%#v expected=%#v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComputeStatusEvents(t *testing.T) {
+	applied := tunedv1.ProfileStatusCondition{
+		Type:    tunedv1.TunedProfileApplied,
+		Status:  corev1.ConditionTrue,
+		Reason:  "AsExpected",
+		Message: "TuneD profile applied.",
+	}
+	notApplied := tunedv1.ProfileStatusCondition{
+		Type:    tunedv1.TunedProfileApplied,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Failed",
+		Message: "The TuneD daemon profile not yet applied, or application failed.",
+	}
+	deferred := tunedv1.ProfileStatusCondition{
+		Type:    tunedv1.TunedProfileApplied,
+		Status:  corev1.ConditionFalse,
+		Reason:  "Deferred",
+		Message: "The TuneD daemon profile is waiting for the next node restart",
+	}
+	notDegraded := tunedv1.ProfileStatusCondition{
+		Type:   tunedv1.TunedDegraded,
+		Status: corev1.ConditionFalse,
+		Reason: "AsExpected",
+	}
+	degraded := tunedv1.ProfileStatusCondition{
+		Type:    tunedv1.TunedDegraded,
+		Status:  corev1.ConditionTrue,
+		Reason:  "TunedError",
+		Message: "TuneD daemon issued one or more error message(s) during profile application.",
+	}
+	degradedDeferred := tunedv1.ProfileStatusCondition{
+		Type:   tunedv1.TunedDegraded,
+		Status: corev1.ConditionTrue,
+		Reason: "TunedDeferredUpdate",
+	}
+
+	appliedEvent := statusEvent{eventtype: corev1.EventTypeNormal, reason: tunedv1.EventReasonProfileApplied, message: "TuneD profile openshift-node applied."}
+	degradedEvent := statusEvent{eventtype: corev1.EventTypeWarning, reason: tunedv1.EventReasonProfileDegraded, message: degraded.Message}
+	deferredEvent := statusEvent{eventtype: corev1.EventTypeNormal, reason: tunedv1.EventReasonDeferredUpdatePending, message: deferred.Message}
+
+	testCases := []struct {
+		name     string
+		reloaded bool
+		old      []tunedv1.ProfileStatusCondition
+		new      []tunedv1.ProfileStatusCondition
+		expected []statusEvent
+	}{
+		{
+			name:     "applied",
+			old:      InitializeStatusConditions(),
+			new:      []tunedv1.ProfileStatusCondition{applied, notDegraded},
+			expected: []statusEvent{appliedEvent},
+		},
+		{
+			name: "no-change",
+			old:  []tunedv1.ProfileStatusCondition{applied, notDegraded},
+			new:  []tunedv1.ProfileStatusCondition{applied, notDegraded},
+		},
+		{
+			name:     "reapplied",
+			reloaded: true,
+			old:      []tunedv1.ProfileStatusCondition{applied, notDegraded},
+			new:      []tunedv1.ProfileStatusCondition{applied, notDegraded},
+			expected: []statusEvent{appliedEvent},
+		},
+		{
+			name:     "degraded",
+			old:      []tunedv1.ProfileStatusCondition{applied, notDegraded},
+			new:      []tunedv1.ProfileStatusCondition{notApplied, degraded},
+			expected: []statusEvent{degradedEvent},
+		},
+		{
+			name:     "deferred",
+			old:      []tunedv1.ProfileStatusCondition{applied, notDegraded},
+			new:      []tunedv1.ProfileStatusCondition{deferred, degradedDeferred},
+			expected: []statusEvent{deferredEvent},
+		},
+		{
+			name: "still-deferred",
+			old:  []tunedv1.ProfileStatusCondition{deferred, degradedDeferred},
+			new:  []tunedv1.ProfileStatusCondition{deferred, degradedDeferred},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := computeStatusEvents(tt.reloaded, "openshift-node", tt.old, tt.new)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got=%#v expected=%#v", got, tt.expected)
+			}
+		})
+	}
+}
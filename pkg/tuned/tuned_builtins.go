@@ -0,0 +1,333 @@
+package tuned
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Sources of the system information used by the TuneD built-in functions.
+// Variables to allow overriding by unit tests.
+var (
+	procCpuinfo   = "/proc/cpuinfo"
+	sysCpuOnline  = "/sys/devices/system/cpu/online"
+	sysCpuPresent = "/sys/devices/system/cpu/present"
+	lscpuCommand  = []string{"lscpu"}
+)
+
+// builtinRegexSearchTernary implements the TuneD built-in function
+// ${f:regex_search_ternary:str:regex:a:b}.  Expands to 'a' if 'regex'
+// matches 'str', otherwise to 'b'.
+func builtinRegexSearchTernary(args []string) (string, error) {
+	if len(args) != 4 {
+		return "", fmt.Errorf("requires 4 arguments")
+	}
+	re, err := regexp.Compile(args[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid regular expression %q: %v", args[1], err)
+	}
+	if re.MatchString(args[0]) {
+		return args[2], nil
+	}
+	return args[3], nil
+}
+
+// builtinRegexCheck expands to the argument following the first regular expression
+// in 'args' which matches 'text'.  Arguments come in pairs of a regular expression
+// and its expansion, with an optional trailing default expansion.  Expands to an
+// empty string if nothing matches and no default expansion is given.  Like TuneD
+// (re.MULTILINE), '^' and '$' match at the line boundaries of 'text'.
+func builtinRegexCheck(text string, args []string) (string, error) {
+	if len(args) < 2 {
+		return "", fmt.Errorf("requires at least 2 arguments")
+	}
+	for i := 0; i+1 < len(args); i += 2 {
+		re, err := regexp.Compile("(?m)" + args[i])
+		if err != nil {
+			return "", fmt.Errorf("invalid regular expression %q: %v", args[i], err)
+		}
+		if re.MatchString(text) {
+			return args[i+1], nil
+		}
+	}
+	if len(args)%2 == 1 {
+		return args[len(args)-1], nil
+	}
+	return "", nil
+}
+
+// builtinCpuinfoCheck implements the TuneD built-in function
+// ${f:cpuinfo_check:regex1:arg1[:regex2:arg2...][:default]}
+// matching the regular expressions against /proc/cpuinfo.
+func builtinCpuinfoCheck(args []string) (string, error) {
+	cpuinfo, err := os.ReadFile(procCpuinfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %v", procCpuinfo, err)
+	}
+	return builtinRegexCheck(string(cpuinfo), args)
+}
+
+// builtinLscpuCheck implements the TuneD built-in function
+// ${f:lscpu_check:regex1:arg1[:regex2:arg2...][:default]}
+// matching the regular expressions against the output of lscpu.
+func builtinLscpuCheck(args []string) (string, error) {
+	lscpu, err := execCmd(lscpuCommand)
+	if err != nil {
+		return "", err
+	}
+	return builtinRegexCheck(lscpu, args)
+}
+
+// cpulistUnpack returns a sorted list of CPUs from the TuneD CPU list 'cpulist'
+// such as "0-3,^2,8".  CPUs prefixed by '^' are excluded from the list.
+func cpulistUnpack(cpulist string) ([]int, error) {
+	var (
+		cpus    = map[int]bool{}
+		exclude = map[int]bool{}
+	)
+
+	for _, item := range strings.Split(cpulist, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		set := cpus
+		if strings.HasPrefix(item, "^") {
+			set = exclude
+			item = item[1:]
+		}
+		first, last, isRange := strings.Cut(item, "-")
+		start, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPU list %q: %v", cpulist, err)
+		}
+		end := start
+		if isRange {
+			end, err = strconv.Atoi(strings.TrimSpace(last))
+			if err != nil {
+				return nil, fmt.Errorf("invalid CPU list %q: %v", cpulist, err)
+			}
+		}
+		if start < 0 || end < start {
+			return nil, fmt.Errorf("invalid CPU range %q in CPU list %q", item, cpulist)
+		}
+		for cpu := start; cpu <= end; cpu++ {
+			set[cpu] = true
+		}
+	}
+
+	ret := []int{}
+	for cpu := range cpus {
+		if !exclude[cpu] {
+			ret = append(ret, cpu)
+		}
+	}
+	sort.Ints(ret)
+
+	return ret, nil
+}
+
+// cpulistPack returns the sorted CPUs 'cpus' as a CPU list with ranges such as "0-3,8".
+func cpulistPack(cpus []int) string {
+	var items []string
+
+	for i := 0; i < len(cpus); {
+		j := i
+		for j+1 < len(cpus) && cpus[j+1] == cpus[j]+1 {
+			j++
+		}
+		if i == j {
+			items = append(items, strconv.Itoa(cpus[i]))
+		} else {
+			items = append(items, fmt.Sprintf("%d-%d", cpus[i], cpus[j]))
+		}
+		i = j + 1
+	}
+
+	return strings.Join(items, ",")
+}
+
+// cpulistString returns the sorted CPUs 'cpus' as a comma-separated list without ranges.
+func cpulistString(cpus []int) string {
+	items := make([]string, 0, len(cpus))
+	for _, cpu := range cpus {
+		items = append(items, strconv.Itoa(cpu))
+	}
+	return strings.Join(items, ",")
+}
+
+// cpulistFile returns the CPUs listed in sysfs file 'file'.
+func cpulistFile(file string) ([]int, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", file, err)
+	}
+	return cpulistUnpack(string(content))
+}
+
+// cpulistFilter returns the CPUs of the TuneD CPU list 'cpulist' which are also listed
+// in sysfs file 'file'.
+func cpulistFilter(cpulist, file string) ([]int, error) {
+	cpus, err := cpulistUnpack(cpulist)
+	if err != nil {
+		return nil, err
+	}
+	fileCpus, err := cpulistFile(file)
+	if err != nil {
+		return nil, err
+	}
+	inFile := make(map[int]bool, len(fileCpus))
+	for _, cpu := range fileCpus {
+		inFile[cpu] = true
+	}
+
+	ret := []int{}
+	for _, cpu := range cpus {
+		if inFile[cpu] {
+			ret = append(ret, cpu)
+		}
+	}
+
+	return ret, nil
+}
+
+// builtinCpulistInvert implements the TuneD built-in function ${f:cpulist_invert:cpulist}.
+// Expands to the online CPUs not present in 'cpulist'.
+func builtinCpulistInvert(cpulist string) (string, error) {
+	online, err := cpulistFile(sysCpuOnline)
+	if err != nil {
+		return "", err
+	}
+	cpus, err := cpulistUnpack(cpulist)
+	if err != nil {
+		return "", err
+	}
+	exclude := make(map[int]bool, len(cpus))
+	for _, cpu := range cpus {
+		exclude[cpu] = true
+	}
+
+	inverted := []int{}
+	for _, cpu := range online {
+		if !exclude[cpu] {
+			inverted = append(inverted, cpu)
+		}
+	}
+
+	return cpulistString(inverted), nil
+}
+
+// cpulist2hex returns the TuneD CPU list 'cpulist' as a hexadecimal CPU mask
+// split into comma-separated groups of 8 digits such as "00000001,0000000f".
+func cpulist2hex(cpulist string) (string, error) {
+	cpus, err := cpulistUnpack(cpulist)
+	if err != nil {
+		return "", err
+	}
+	mask := new(big.Int)
+	for _, cpu := range cpus {
+		mask.SetBit(mask, cpu, 1)
+	}
+	s := mask.Text(16)
+	if len(s)%8 != 0 {
+		s = strings.Repeat("0", 8-len(s)%8) + s
+	}
+
+	var groups []string
+	for i := 0; i < len(s); i += 8 {
+		groups = append(groups, s[i:i+8])
+	}
+
+	return strings.Join(groups, ","), nil
+}
+
+// hex2cpulist returns the hexadecimal CPU mask 'hex' such as "0x3" or "00000001,0000000f"
+// as a comma-separated list of CPUs.
+func hex2cpulist(hex string) (string, error) {
+	s := strings.TrimPrefix(strings.ToLower(strings.TrimSpace(hex)), "0x")
+	s = strings.ReplaceAll(s, ",", "")
+	mask, ok := new(big.Int).SetString(s, 16)
+	if !ok {
+		return "", fmt.Errorf("invalid hexadecimal CPU mask %q", hex)
+	}
+
+	cpus := []int{}
+	for cpu := 0; cpu < mask.BitLen(); cpu++ {
+		if mask.Bit(cpu) == 1 {
+			cpus = append(cpus, cpu)
+		}
+	}
+
+	return cpulistString(cpus), nil
+}
+
+// execTuneDBuiltinNative executes TuneD built-in function 'function' implemented
+// natively with arguments 'args'.  Returns false if 'function' is not implemented.
+func execTuneDBuiltinNative(function string, args []string) (string, bool, error) {
+	// TuneD CPU list built-ins take CPU lists split by the ':' argument separator.
+	cpulist := strings.Join(args, ",")
+
+	switch function {
+	case "regex_search_ternary":
+		out, err := builtinRegexSearchTernary(args)
+		return out, true, err
+
+	case "cpuinfo_check":
+		out, err := builtinCpuinfoCheck(args)
+		return out, true, err
+
+	case "lscpu_check":
+		out, err := builtinLscpuCheck(args)
+		return out, true, err
+
+	case "strip":
+		return strings.TrimSpace(strings.Join(args, "")), true, nil
+
+	case "cpulist_unpack":
+		cpus, err := cpulistUnpack(cpulist)
+		return cpulistString(cpus), true, err
+
+	case "cpulist_pack":
+		cpus, err := cpulistUnpack(cpulist)
+		return cpulistPack(cpus), true, err
+
+	case "cpulist_invert":
+		out, err := builtinCpulistInvert(cpulist)
+		return out, true, err
+
+	case "cpulist_online":
+		cpus, err := cpulistFilter(cpulist, sysCpuOnline)
+		return cpulistString(cpus), true, err
+
+	case "cpulist_present":
+		cpus, err := cpulistFilter(cpulist, sysCpuPresent)
+		return cpulistString(cpus), true, err
+
+	case "cpulist2hex":
+		out, err := cpulist2hex(cpulist)
+		return out, true, err
+
+	case "hex2cpulist":
+		out, err := hex2cpulist(strings.Join(args, ""))
+		return out, true, err
+
+	case "kb2s", "s2kb":
+		if len(args) != 1 {
+			return "", true, fmt.Errorf("requires 1 argument")
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(args[0]), 10, 64)
+		if err != nil {
+			return "", true, fmt.Errorf("invalid number %q: %v", args[0], err)
+		}
+		if function == "kb2s" {
+			return strconv.FormatInt(n*2, 10), true, nil
+		}
+		return strconv.FormatInt(n/2, 10), true, nil
+	}
+
+	return "", false, nil
+}
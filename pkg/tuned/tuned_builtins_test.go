@@ -0,0 +1,151 @@
+package tuned
+
+import (
+	"testing"
+)
+
+// useBuiltinFixtures points the TuneD built-in functions to the fixture files
+// in testdata/builtins for the duration of test 't'.
+func useBuiltinFixtures(t *testing.T) {
+	procCpuinfoOrig, sysCpuOnlineOrig, sysCpuPresentOrig, lscpuCommandOrig := procCpuinfo, sysCpuOnline, sysCpuPresent, lscpuCommand
+	t.Cleanup(func() {
+		procCpuinfo, sysCpuOnline, sysCpuPresent, lscpuCommand = procCpuinfoOrig, sysCpuOnlineOrig, sysCpuPresentOrig, lscpuCommandOrig
+	})
+
+	procCpuinfo = "testdata/builtins/cpuinfo"
+	sysCpuOnline = "testdata/builtins/online"
+	sysCpuPresent = "testdata/builtins/present"
+	lscpuCommand = []string{"cat", "testdata/builtins/lscpu"}
+}
+
+func TestBuiltinExpansionNative(t *testing.T) {
+	useBuiltinFixtures(t)
+
+	var tests = []struct {
+		input          string
+		expectedOutput string
+	}{
+		// regex_search_ternary
+		{
+			input:          "cpu-partitioning${f:regex_search_ternary:5.14.0-284.rt14.el9.x86_64:rt:,realtime:}",
+			expectedOutput: "cpu-partitioning,realtime",
+		},
+		{
+			input:          "cpu-partitioning${f:regex_search_ternary:5.14.0-284.el9.x86_64:rt:,realtime:}",
+			expectedOutput: "cpu-partitioning",
+		},
+		{
+			input:          "${f:regex_search_ternary:${f:exec:printf:rt}:^rt$:realtime:throughput}",
+			expectedOutput: "realtime",
+		},
+		{
+			input:          "${f:regex_search_ternary:a:b}",
+			expectedOutput: "${f:regex_search_ternary:a:b}",
+		},
+		// lscpu_check
+		{
+			input:          `performance-${f:lscpu_check:Vendor ID\:\s*GenuineIntel:intel:Vendor ID\:\s*AuthenticAMD:amd:Vendor ID\:\s*ARM:arm}-${f:lscpu_check:Architecture\:\s*x86_64:x86:Architecture\:\s*aarch64:aarch64}`,
+			expectedOutput: "performance-intel-x86",
+		},
+		{
+			input:          `${f:lscpu_check:Vendor ID\:\s*AuthenticAMD:amd}`,
+			expectedOutput: "",
+		},
+		{
+			input:          `${f:lscpu_check:Vendor ID\:\s*AuthenticAMD:amd:unknown}`,
+			expectedOutput: "unknown",
+		},
+		{
+			// Anchors match at line boundaries.
+			input:          `${f:lscpu_check:^Vendor ID\:\s*GenuineIntel$:intel:unknown}`,
+			expectedOutput: "intel",
+		},
+		// cpuinfo_check
+		{
+			input:          `${f:cpuinfo_check:flags\s*\:.*\bavx512f\b:avx512:flags\s*\:.*\bpdpe1gb\b:1g:none}`,
+			expectedOutput: "1g",
+		},
+		{
+			input:          `${f:cpuinfo_check:vendor_id\s*\:\s*AuthenticAMD:amd}`,
+			expectedOutput: "",
+		},
+		{
+			input:          `${f:cpuinfo_check:^vendor_id\s*\:\s*GenuineIntel:intel:unknown}`,
+			expectedOutput: "intel",
+		},
+		// strip
+		{
+			input:          "${f:strip:  a : b  }",
+			expectedOutput: "a  b",
+		},
+		// CPU lists
+		{
+			input:          "${f:cpulist_unpack:0-3,^2,8}",
+			expectedOutput: "0,1,3,8",
+		},
+		{
+			input:          "${f:cpulist_unpack:0-1:4}",
+			expectedOutput: "0,1,4",
+		},
+		{
+			input:          "${f:cpulist_pack:0,1,2,3,5,7,8}",
+			expectedOutput: "0-3,5,7-8",
+		},
+		{
+			input:          "${f:cpulist_invert:1-2}",
+			expectedOutput: "0,3,4,5,7",
+		},
+		{
+			input:          "${f:cpulist_online:4-7}",
+			expectedOutput: "4,5,7",
+		},
+		{
+			input:          "${f:cpulist_present:6-9}",
+			expectedOutput: "6,7",
+		},
+		{
+			input:          "${f:cpulist2hex:0-3,32}",
+			expectedOutput: "00000001,0000000f",
+		},
+		{
+			input:          "${f:hex2cpulist:00000001,0000000f}",
+			expectedOutput: "0,1,2,3,32",
+		},
+		{
+			input:          "${f:hex2cpulist:0x5}",
+			expectedOutput: "0,2",
+		},
+		{
+			input:          "${f:cpulist_unpack:0-a}",
+			expectedOutput: "${f:cpulist_unpack:0-a}",
+		},
+		// Unit conversions
+		{
+			input:          "${f:kb2s:512}",
+			expectedOutput: "1024",
+		},
+		{
+			input:          "${f:s2kb:1024}",
+			expectedOutput: "512",
+		},
+		// Unsupported built-in
+		{
+			input:          "${f:unsupported_builtin:a}",
+			expectedOutput: "${f:unsupported_builtin:a}",
+		},
+	}
+
+	for i, tc := range tests {
+		actual := expandTuneDBuiltin(tc.input)
+
+		if actual != tc.expectedOutput {
+			t.Errorf(
+				"failed test case %d:\n\t  in: %s\n\twant: %s\n\thave: %s",
+				i+1,
+				tc.input,
+				tc.expectedOutput,
+				actual,
+			)
+		}
+	}
+}
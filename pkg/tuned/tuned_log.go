@@ -0,0 +1,152 @@
+package tuned
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// maxTunedLogMessages is the maximum number of TuneD daemon warnings and errors
+	// reported in the Profile status.
+	maxTunedLogMessages = 20
+	// maxTunedLogMessagesKept is the maximum number of TuneD daemon warnings and errors
+	// kept since the TuneD daemon was last (re)loaded; the oldest ones are dropped.
+	maxTunedLogMessagesKept = 5 * maxTunedLogMessages
+
+	tunedLogLevelWarning  = "WARNING"
+	tunedLogLevelError    = "ERROR"
+	tunedLogLevelCritical = "CRITICAL"
+
+	tunedPluginLoggerPrefix = "tuned.plugins.plugin_"
+)
+
+var (
+	// tunedLogLineRegex matches the TuneD daemon log lines in the format
+	// "<date> <time> <level> <logger>: <message>".  The timestamp and the logger are
+	// optional, so that lines logged before TuneD configures its logging, such as
+	// "<level>:<logger>:<message>" or "<level>: <message>", are matched too.
+	tunedLogLineRegex = regexp.MustCompile(`^(?:\S+ \S+ )?(DEBUG|INFO|WARNING|ERROR|CRITICAL)(?:(?:\s+|:)([\w.]+))?:\s*(.*)$`)
+	// tunedInstanceRegex matches TuneD plugin instance names at the start of a log message.
+	tunedInstanceRegex = regexp.MustCompile(`^instance '?([^':\s]+)'?: `)
+)
+
+// tunedLogMessage is a warning or an error issued by the TuneD daemon.
+type tunedLogMessage struct {
+	level    string // log level; WARNING, ERROR or CRITICAL
+	logger   string // TuneD logger name, e.g. tuned.plugins.plugin_sysctl
+	plugin   string // TuneD plugin name; empty for messages not issued by a plugin
+	instance string // TuneD plugin instance name (if known)
+	message  string
+	text     string // the log line without the timestamp
+}
+
+// isError returns true if the TuneD daemon log message is an error.
+func (m tunedLogMessage) isError() bool {
+	return m.level == tunedLogLevelError || m.level == tunedLogLevelCritical
+}
+
+// source returns a human-readable name of the TuneD plugin and instance
+// or the TuneD logger which issued the message.
+func (m tunedLogMessage) source() string {
+	switch {
+	case len(m.plugin) > 0 && len(m.instance) > 0:
+		return fmt.Sprintf("plugin %s, instance %s", m.plugin, m.instance)
+	case len(m.plugin) > 0:
+		return "plugin " + m.plugin
+	case len(m.instance) > 0:
+		return "instance " + m.instance
+	case len(m.logger) > 0:
+		return m.logger
+	}
+	return "TuneD"
+}
+
+// parseTunedLogLine parses TuneD daemon log line 'line'.  Returns the parsed
+// message and true if the line is a TuneD warning or error.
+func parseTunedLogLine(line string) (tunedLogMessage, bool) {
+	match := tunedLogLineRegex.FindStringSubmatchIndex(line)
+	if match == nil {
+		return tunedLogMessage{}, false
+	}
+
+	msg := tunedLogMessage{
+		level:   line[match[2]:match[3]],
+		message: line[match[6]:match[7]],
+		text:    " " + line[match[2]:],
+	}
+	if match[4] >= 0 {
+		msg.logger = line[match[4]:match[5]]
+	}
+	if msg.level != tunedLogLevelWarning && !msg.isError() {
+		return tunedLogMessage{}, false
+	}
+
+	msg.plugin = strings.TrimPrefix(msg.logger, tunedPluginLoggerPrefix)
+	if msg.plugin == msg.logger {
+		msg.plugin = ""
+	}
+	if instance := tunedInstanceRegex.FindStringSubmatch(msg.message); instance != nil {
+		msg.instance = instance[1]
+		msg.message = msg.message[len(instance[0]):]
+	}
+
+	return msg, true
+}
+
+// tunedLogMessages are the warnings and errors issued by the TuneD daemon since
+// it was last (re)loaded, in the order they were issued.  Repeated messages are
+// kept only once.
+type tunedLogMessages []tunedLogMessage
+
+// add appends TuneD log message 'msg' unless already present.  Only the newest
+// maxTunedLogMessagesKept messages are kept.
+func (ms tunedLogMessages) add(msg tunedLogMessage) tunedLogMessages {
+	for _, m := range ms {
+		if m.level == msg.level && m.logger == msg.logger && m.instance == msg.instance && m.message == msg.message {
+			return ms
+		}
+	}
+	if len(ms) >= maxTunedLogMessagesKept {
+		ms = ms[len(ms)-maxTunedLogMessagesKept+1:]
+	}
+	return append(ms, msg)
+}
+
+// String returns a human-readable list of the TuneD log messages grouped by
+// the TuneD plugin and instance or the TuneD logger which issued them.  Errors
+// are listed before warnings.
+func (ms tunedLogMessages) String() string {
+	var (
+		sources []string
+		grouped = map[string][]string{}
+		n       int
+	)
+
+	for _, errors := range []bool{true, false} {
+		for _, m := range ms {
+			if m.isError() != errors {
+				continue
+			}
+			n++
+			if n > maxTunedLogMessages {
+				continue
+			}
+			source := m.source()
+			if _, ok := grouped[source]; !ok {
+				sources = append(sources, source)
+			}
+			grouped[source] = append(grouped[source], m.level+": "+m.message)
+		}
+	}
+
+	items := make([]string, 0, len(sources))
+	for _, source := range sources {
+		items = append(items, fmt.Sprintf("[%s] %s", source, strings.Join(grouped[source], "; ")))
+	}
+	if n > maxTunedLogMessages {
+		items = append(items, fmt.Sprintf("and %d more", n-maxTunedLogMessages))
+	}
+
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,159 @@
+package tuned
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParseTunedLogLine(t *testing.T) {
+	testCases := []struct {
+		name     string
+		line     string
+		expected tunedLogMessage
+		ok       bool
+	}{
+		{
+			name: "info",
+			line: "2024-05-02 10:00:00,123 INFO     tuned.daemon.daemon: static tuning from profile 'openshift-node' applied",
+		},
+		{
+			name: "not a log line",
+			line: "Traceback (most recent call last):",
+		},
+		{
+			name: "plugin error",
+			line: "2024-05-02 10:00:00,123 ERROR    tuned.plugins.plugin_sysctl: Failed to read sysctl parameter 'net.ipv4.foo', the parameter does not exist",
+			expected: tunedLogMessage{
+				level:   "ERROR",
+				logger:  "tuned.plugins.plugin_sysctl",
+				plugin:  "sysctl",
+				message: "Failed to read sysctl parameter 'net.ipv4.foo', the parameter does not exist",
+				text:    " ERROR    tuned.plugins.plugin_sysctl: Failed to read sysctl parameter 'net.ipv4.foo', the parameter does not exist",
+			},
+			ok: true,
+		},
+		{
+			name: "instance warning",
+			line: "2024-05-02 10:00:00,123 WARNING  tuned.plugins.base: instance net: no matching devices available",
+			expected: tunedLogMessage{
+				level:    "WARNING",
+				logger:   "tuned.plugins.base",
+				instance: "net",
+				message:  "no matching devices available",
+				text:     " WARNING  tuned.plugins.base: instance net: no matching devices available",
+			},
+			ok: true,
+		},
+		{
+			name: "plugin instance error",
+			line: "2024-05-02 10:00:00,123 ERROR    tuned.plugins.plugin_cpu: instance 'cpu_isolated': failed to set governor",
+			expected: tunedLogMessage{
+				level:    "ERROR",
+				logger:   "tuned.plugins.plugin_cpu",
+				plugin:   "cpu",
+				instance: "cpu_isolated",
+				message:  "failed to set governor",
+				text:     " ERROR    tuned.plugins.plugin_cpu: instance 'cpu_isolated': failed to set governor",
+			},
+			ok: true,
+		},
+		{
+			name: "error without timestamp",
+			line: "ERROR    tuned.daemon.daemon: Cannot set initial profile",
+			expected: tunedLogMessage{
+				level:   "ERROR",
+				logger:  "tuned.daemon.daemon",
+				message: "Cannot set initial profile",
+				text:    " ERROR    tuned.daemon.daemon: Cannot set initial profile",
+			},
+			ok: true,
+		},
+		{
+			name: "python default format",
+			line: "ERROR:tuned.utils.global_config:error parsing global configuration file",
+			expected: tunedLogMessage{
+				level:   "ERROR",
+				logger:  "tuned.utils.global_config",
+				message: "error parsing global configuration file",
+				text:    " ERROR:tuned.utils.global_config:error parsing global configuration file",
+			},
+			ok: true,
+		},
+		{
+			name: "error without logger",
+			line: "CRITICAL: cannot load TuneD profiles",
+			expected: tunedLogMessage{
+				level:   "CRITICAL",
+				message: "cannot load TuneD profiles",
+				text:    " CRITICAL: cannot load TuneD profiles",
+			},
+			ok: true,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseTunedLogLine(tt.line)
+			if ok != tt.ok || !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got=%#v,%v expected=%#v,%v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestTunedLogMessagesString(t *testing.T) {
+	lines := []string{
+		"2024-05-02 10:00:00,100 WARNING  tuned.plugins.base: instance net: no matching devices available",
+		"2024-05-02 10:00:00,200 ERROR    tuned.plugins.plugin_sysctl: Failed to set sysctl parameter 'a'",
+		"2024-05-02 10:00:00,300 ERROR    tuned.plugins.plugin_sysctl: Failed to set sysctl parameter 'b'",
+		"2024-05-02 10:00:00,400 ERROR    tuned.plugins.plugin_sysctl: Failed to set sysctl parameter 'a'",
+		"2024-05-02 10:00:00,500 ERROR    tuned.daemon.daemon: Cannot set initial profile",
+	}
+
+	var messages tunedLogMessages
+	for _, line := range lines {
+		if msg, ok := parseTunedLogLine(line); ok {
+			messages = messages.add(msg)
+		}
+	}
+
+	expected := "[plugin sysctl] ERROR: Failed to set sysctl parameter 'a'; ERROR: Failed to set sysctl parameter 'b', " +
+		"[tuned.daemon.daemon] ERROR: Cannot set initial profile, " +
+		"[instance net] WARNING: no matching devices available"
+	if got := messages.String(); got != expected {
+		t.Errorf("got=%q expected=%q", got, expected)
+	}
+
+	messages = nil
+	for i := 0; i < maxTunedLogMessages+2; i++ {
+		msg, _ := parseTunedLogLine(fmt.Sprintf("2024-05-02 10:00:00,100 ERROR    tuned.plugins.plugin_sysfs: error %d", i))
+		messages = messages.add(msg)
+	}
+	if got, suffix := messages.String(), ", and 2 more"; got[len(got)-len(suffix):] != suffix {
+		t.Errorf("got=%q expected suffix %q", got, suffix)
+	}
+}
+
+func TestTunedLogMessagesAdd(t *testing.T) {
+	var messages tunedLogMessages
+	for i := 0; i < maxTunedLogMessagesKept+5; i++ {
+		msg, _ := parseTunedLogLine(fmt.Sprintf("ERROR: error %d", i))
+		messages = messages.add(msg)
+		// Repeated messages are kept only once.
+		messages = messages.add(msg)
+	}
+
+	if len(messages) != maxTunedLogMessagesKept {
+		t.Fatalf("got=%d messages expected=%d", len(messages), maxTunedLogMessagesKept)
+	}
+	if got, expected := messages[0].message, "error 5"; got != expected {
+		t.Errorf("got oldest=%q expected=%q", got, expected)
+	}
+	if got, expected := messages[len(messages)-1].message, fmt.Sprintf("error %d", maxTunedLogMessagesKept+4); got != expected {
+		t.Errorf("got newest=%q expected=%q", got, expected)
+	}
+	if got, expected := messages[0].source(), "TuneD"; got != expected {
+		t.Errorf("got source=%q expected=%q", got, expected)
+	}
+}
@@ -12,6 +12,8 @@ import (
 
 	"gopkg.in/ini.v1"
 	"k8s.io/klog/v2"
+
+	"github.com/openshift/cluster-node-tuning-operator/pkg/tunedprofile"
 )
 
 // iniFileLoad reads INI file `iniFile` into ini.v1 internal data structures.
@@ -80,99 +82,54 @@ func iniCfgSetKey(cfg *ini.File, key string, value interface{}) error {
 	return nil
 }
 
-// getIniFileSectionSlice searches INI file `data` inside [`section`]
-// for key `key`.  It takes the key's value and uses separator
-// `separator` to return a slice of strings.
-func getIniFileSectionSlice(data *string, section, key, separator string) []string {
-	var ret []string
+// profileIncludesDir returns a slice of strings containing TuneD profile names
+// profile <tunedProfilesDir>/<profileName> includes.  Expansion of TuneD built-in
+// functions is performed on the include= option before it is split into the
+// profile names and optional loading characters ('-') are removed.
+func profileIncludesDir(profileName string, tunedProfilesDir string) []string {
+	var profiles []string
+
+	profileFile := fmt.Sprintf("%s/%s/%s", tunedProfilesDir, profileName, tunedConfFile)
 
-	if data == nil {
-		return ret
+	content, err := os.ReadFile(profileFile)
+	if err != nil {
+		return profiles
 	}
 
-	cfg, err := ini.Load([]byte(*data))
-	if err != nil || cfg == nil {
+	cfg, err := tunedprofile.Parse(content)
+	if err != nil {
 		// This looks like an invalid INI data or parser error.
 		klog.Errorf("unable to read INI file data: %v", err)
-		return ret
+		return profiles
 	}
 
-	if !cfg.Section(section).HasKey(key) {
-		return ret
+	for _, include := range tunedprofile.Includes(cfg, expandTuneDBuiltin) {
+		profiles = append(profiles, include.Name)
 	}
 
-	ret = strings.Split(cfg.Section(section).Key(key).String(), separator)
-
-	return ret
-}
-
-// profileIncludesRaw returns a slice of strings containing TuneD profile names
-// profile <tunedProfilesDir>/<profileName> includes.  The profile names may
-// contain built-in functions that still need to be expanded.
-func profileIncludesRaw(profileName string, tunedProfilesDir string) []string {
-	profileFile := fmt.Sprintf("%s/%s/%s", tunedProfilesDir, profileName, tunedConfFile)
-
-	content, err := os.ReadFile(profileFile)
-	if err != nil {
-		content = []byte{}
-	}
-
-	s := string(content)
-
-	return getIniFileSectionSlice(&s, "main", "include", ",")
+	return profiles
 }
 
 // profileIncludes returns a slice of strings containing TuneD profile names
-// profile 'profileName' includes.  Expansion of TuneD built-in functions is
-// performed on the included profile names and optional loading characters
-// ('-') removed.  Only custom <tunedProfilesDirCustom>/<profileName> and/or
-// system <tunedProfilesDirSystem>/<profileName> TuneD profiles are scanned.
+// profile 'profileName' includes.  Only custom <tunedProfilesDirCustom>/<profileName>
+// and/or system <tunedProfilesDirSystem>/<profileName> TuneD profiles are scanned.
 // For a full list of profiles included from other profiles 'profileName'
 // depends on use profileDepends function.
 func profileIncludes(profileName string) []string {
-	var (
-		custom   bool
-		system   bool
-		profiles []string
-		expanded []string
-	)
-
-	if profileExists(profileName, tunedProfilesDirCustom) {
-		custom = true
-		profiles = profileIncludesRaw(profileName, tunedProfilesDirCustom)
-	} else {
-		profiles = profileIncludesRaw(profileName, tunedProfilesDirSystem)
-	}
-
-	realProfileName := func(profile string) string {
-		if profile[0:1] == "-" {
-			// Conditional profile loading, strip the '-' from profile name.
-			profile = profile[1:]
-		}
-
-		return expandTuneDBuiltin(profile)
+	if !profileExists(profileName, tunedProfilesDirCustom) {
+		return profileIncludesDir(profileName, tunedProfilesDirSystem)
 	}
 
+	profiles := profileIncludesDir(profileName, tunedProfilesDirCustom)
 	for _, profile := range profiles {
-		p := realProfileName(profile)
-		if profileName == p && custom {
+		if profile == profileName {
 			// Custom profile 'profileName' includes profile of the same name.
 			// We need to get included profiles from system profile 'profileName' too.
-			system = true
+			return append(profiles, profileIncludesDir(profileName, tunedProfilesDirSystem)...)
 		}
-		expanded = append(expanded, p)
-	}
-
-	if !system {
-		return expanded
-	}
-
-	for _, profile := range profileIncludesRaw(profileName, tunedProfilesDirSystem) {
-		p := realProfileName(profile)
-		expanded = append(expanded, p)
 	}
 
-	return expanded
+	return profiles
 }
 
 // profileExists returns true if TuneD profile <tunedProfilesDir>/<profileName> exists.
@@ -210,6 +167,22 @@ func profileDependsLoop(profileName string, seenProfiles map[string]bool) map[st
 	return seenProfiles
 }
 
+// EffectiveProfile returns the effective TuneD profile 'profileName' as TuneD
+// applies it after resolving its includes from the custom and system TuneD
+// profile directories and merging the included profiles.
+func EffectiveProfile(profileName string) (*tunedprofile.Profile, error) {
+	return effectiveProfilePath(profileName, tunedProfilesDirCustom, tunedProfilesDirSystem)
+}
+
+// effectiveProfilePath is like EffectiveProfile but takes explicit custom and
+// system profiles directory paths, so it's easier to test.  To be used only internally.
+func effectiveProfilePath(profileName, profilesDirCustom, profilesDirSystem string) (*tunedprofile.Profile, error) {
+	loader := tunedprofile.NewLoader(profilesDirSystem, profilesDirCustom)
+	loader.Expand = expandTuneDBuiltin
+
+	return loader.Load(strings.Fields(profileName)...)
+}
+
 // execCmd starts command 'command' and waits for it to complete.
 // Optional arguments for the command start at command[1].
 // If the command does not exit within 'waitSeconds' seconds, SIGTERM
@@ -313,6 +286,11 @@ func execCmd(command []string) (string, error) {
 // arguments 'args'.  Returns the result/expansion of running the built-in.
 // If the execution of the built-in fails, returns the string 'onFail'.
 func execTuneDBuiltin(function string, args []string, onFail string) string {
+	for i := range args {
+		// Unescape the argument separators.
+		args[i] = strings.ReplaceAll(args[i], `\:`, ":")
+	}
+
 	switch {
 	case function == "exec":
 		out, err := execCmd(args)
@@ -344,8 +322,17 @@ func execTuneDBuiltin(function string, args []string, onFail string) string {
 		return args[1]
 
 	default:
-		klog.Errorf("calling unsupported built-in: %v", function)
-		// unsupported built-in
+		out, ok, err := execTuneDBuiltinNative(function, args)
+		if !ok {
+			klog.Errorf("calling unsupported built-in: %v", function)
+			// unsupported built-in
+			break
+		}
+		if err != nil {
+			klog.Errorf("error calling built-in %s: %v", function, err)
+			return onFail
+		}
+		return out
 	}
 
 	return onFail
@@ -353,10 +340,10 @@ func execTuneDBuiltin(function string, args []string, onFail string) string {
 
 // expandTuneDBuiltin is a naive and incomplete parser of TuneD built-in
 // functions in the form ${f:function(:argN)*}.  A typical use case is
-// evaluating ${f:virt_check:profile-a:profile-b} and ${f:exec(:argN)}
-// TuneD built-in functions in "include" statements.  If (parts of)
-// the expansion fail, the function returns the original string for the
-// parts that failed the expansion.
+// evaluating ${f:virt_check:profile-a:profile-b}, ${f:exec(:argN)} or
+// ${f:regex_search_ternary:...} TuneD built-in functions in "include"
+// statements.  If (parts of) the expansion fail, the function returns
+// the original string for the parts that failed the expansion.
 func expandTuneDBuiltin(s string) string {
 	const (
 		sInit   = 0
@@ -0,0 +1,182 @@
+package tuned
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/klog/v2"
+
+	"github.com/openshift/cluster-node-tuning-operator/pkg/tunedprofile"
+)
+
+const (
+	procSysDir = "/proc/sys"
+	// maxDriftedReported is the maximum number of drifted settings reported in the Profile status.
+	maxDriftedReported = 10
+)
+
+// tunedPluginOptions are the TuneD options common to all plugins which are not
+// settings to verify.
+var tunedPluginOptions = map[string]bool{
+	"devices":            true,
+	"devices_udev_regex": true,
+	"cpuinfo_regex":      true,
+	"enabled":            true,
+	"priority":           true,
+	"replace":            true,
+	"script_pre":         true,
+	"script_post":        true,
+	"type":               true,
+	"uname_regex":        true,
+}
+
+// profileSettings holds the merged settings of the supported TuneD plugins
+// (indexed by plugin name) of a TuneD profile including the profiles it includes.
+type profileSettings map[string]map[string]string
+
+// profileSettingsLoad returns the merged settings of TuneD plugins 'plugins' of
+// the active TuneD profile(s) 'activeProfile'.  No settings are returned if the
+// effective TuneD profile cannot be loaded.
+func profileSettingsLoad(activeProfile string, plugins []string) profileSettings {
+	profile, err := EffectiveProfile(activeProfile)
+	if err != nil {
+		klog.V(2).Infof("profileSettingsLoad(): %v", err)
+	}
+
+	return profileSettingsGet(profile, plugins)
+}
+
+// profileSettingsGet returns the settings of TuneD plugins 'plugins' of the effective
+// TuneD profile 'profile'.  The units are merged by TuneD by their names, the plugin
+// of a unit is selected by its type= option and defaults to the unit name.
+func profileSettingsGet(profile *tunedprofile.Profile, plugins []string) profileSettings {
+	settings := profileSettings{}
+	for _, plugin := range plugins {
+		settings[plugin] = map[string]string{}
+	}
+	if profile == nil {
+		return settings
+	}
+
+	for _, unit := range profile.Units {
+		plugin := unit.Name
+		if t, ok := unit.Options["type"]; ok {
+			plugin = t
+		}
+		if _, ok := settings[plugin]; !ok {
+			continue
+		}
+		for option, value := range unit.Options {
+			if tunedPluginOptions[option] {
+				continue
+			}
+			settings[plugin][option] = value
+		}
+	}
+
+	return settings
+}
+
+// normalizeValue returns value 'value' with whitespace normalized the way
+// the kernel reports multi-value settings.
+func normalizeValue(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// sysfsValue returns the value of a sysfs file content 'content'.  For files
+// listing all the options with the current one in brackets, only the current
+// option is returned.
+func sysfsValue(content string) string {
+	content = normalizeValue(content)
+	start := strings.Index(content, "[")
+	end := strings.Index(content, "]")
+	if start >= 0 && end > start {
+		return content[start+1 : end]
+	}
+	return content
+}
+
+// verifiable returns true if setting value 'value' can be verified, i.e. it
+// does not use TuneD variables, built-in functions or value operators.
+func verifiable(value string) bool {
+	return len(value) > 0 && !strings.Contains(value, "${") && !strings.ContainsAny(value[0:1], "<>")
+}
+
+// verifySysctl returns the sysctl settings 'sysctls' which no longer match
+// the values under 'procSys' directory.
+func verifySysctl(sysctls map[string]string, procSys string) []string {
+	var drifted []string
+
+	for key, want := range sysctls {
+		if !verifiable(want) {
+			continue
+		}
+		path := key
+		if !strings.Contains(path, "/") {
+			path = strings.ReplaceAll(path, ".", "/")
+		}
+		content, err := os.ReadFile(filepath.Join(procSys, path))
+		if err != nil {
+			// TuneD skips sysctls which do not exist.
+			continue
+		}
+		if have := normalizeValue(string(content)); have != normalizeValue(want) {
+			drifted = append(drifted, fmt.Sprintf("sysctl %s=%s (have %s)", key, want, have))
+		}
+	}
+
+	return drifted
+}
+
+// verifySysfs returns the sysfs settings 'sysfs' which no longer match
+// the values of the sysfs files.  'root' is prepended to the sysfs paths.
+func verifySysfs(sysfs map[string]string, root string) []string {
+	var drifted []string
+
+	for key, want := range sysfs {
+		if !verifiable(want) {
+			continue
+		}
+		paths, err := filepath.Glob(filepath.Join(root, key))
+		if err != nil {
+			continue
+		}
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			if have := sysfsValue(string(content)); have != normalizeValue(want) {
+				rel, err := filepath.Rel(root, path)
+				if err != nil {
+					rel = path
+				}
+				drifted = append(drifted, fmt.Sprintf("sysfs %s=%s (have %s)", filepath.Join("/", rel), want, have))
+			}
+		}
+	}
+
+	return drifted
+}
+
+// verifyProfile returns a sorted list of sysctl and sysfs settings of the
+// active TuneD profile(s) 'activeProfile' which no longer match the node state.
+func verifyProfile(activeProfile string) []string {
+	settings := profileSettingsLoad(activeProfile, []string{"sysctl", "sysfs"})
+
+	drifted := append(verifySysctl(settings["sysctl"], procSysDir), verifySysfs(settings["sysfs"], "/")...)
+	sort.Strings(drifted)
+
+	return drifted
+}
+
+// driftedMessage returns a human-readable list of drifted settings 'drifted'.
+func driftedMessage(drifted []string) string {
+	if len(drifted) <= maxDriftedReported {
+		return strings.Join(drifted, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(drifted[:maxDriftedReported], ", "), len(drifted)-maxDriftedReported)
+}
@@ -0,0 +1,140 @@
+package tuned
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProfileSettingsGet(t *testing.T) {
+	customDir := t.TempDir()
+	systemDir := t.TempDir()
+
+	writeTestFile(t, filepath.Join(systemDir, "base", tunedConfFile), "[sysctl]\nvm.swappiness=30\nkernel.pid_max=4194304\n")
+	writeTestFile(t, filepath.Join(systemDir, "openshift", tunedConfFile), "[main]\ninclude=base\n[sysctl]\nvm.swappiness=20\n")
+	writeTestFile(t, filepath.Join(customDir, "openshift", tunedConfFile), "[main]\ninclude=openshift\n[sysctl]\nnet.core.somaxconn=2048\n")
+	writeTestFile(t, filepath.Join(customDir, "custom", tunedConfFile), "[main]\ninclude=openshift,-missing\n[sysctl]\nvm.swappiness=10\npriority=10\n[sysfs_thp]\ntype=sysfs\n/sys/kernel/mm/transparent_hugepage/enabled=never\n")
+	writeTestFile(t, filepath.Join(customDir, "replace", tunedConfFile), "[main]\ninclude=openshift\n[sysctl]\nreplace=true\nvm.swappiness=10\n")
+	writeTestFile(t, filepath.Join(customDir, "drop", tunedConfFile), "[main]\ninclude=openshift\n[sysctl]\ndrop=kernel.pid_max\n")
+	writeTestFile(t, filepath.Join(customDir, "replace-unit", tunedConfFile), "[main]\ninclude=base,custom\n[sysfs]\nreplace=true\n/sys/kernel/mm/ksm/run=0\n")
+
+	testCases := []struct {
+		name     string
+		profile  string
+		expected profileSettings
+	}{
+		{
+			name:    "includes",
+			profile: "custom",
+			expected: profileSettings{
+				"sysctl": {
+					"kernel.pid_max":     "4194304",
+					"net.core.somaxconn": "2048",
+					"vm.swappiness":      "10",
+				},
+				"sysfs": {
+					"/sys/kernel/mm/transparent_hugepage/enabled": "never",
+				},
+			},
+		},
+		{
+			name:    "replace",
+			profile: "replace",
+			expected: profileSettings{
+				"sysctl": {
+					"vm.swappiness": "10",
+				},
+				"sysfs": {},
+			},
+		},
+		{
+			name:    "drop",
+			profile: "drop",
+			expected: profileSettings{
+				"sysctl": {
+					"net.core.somaxconn": "2048",
+					"vm.swappiness":      "20",
+				},
+				"sysfs": {},
+			},
+		},
+		{
+			// Units are replaced by their names, not by their plugin types.
+			name:    "replace unit",
+			profile: "replace-unit",
+			expected: profileSettings{
+				"sysctl": {
+					"kernel.pid_max":     "4194304",
+					"net.core.somaxconn": "2048",
+					"vm.swappiness":      "10",
+				},
+				"sysfs": {
+					"/sys/kernel/mm/ksm/run":                      "0",
+					"/sys/kernel/mm/transparent_hugepage/enabled": "never",
+				},
+			},
+		},
+		{
+			name:    "missing",
+			profile: "missing",
+			expected: profileSettings{
+				"sysctl": {},
+				"sysfs":  {},
+			},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Profiles which cannot be loaded have no settings.
+			profile, _ := effectiveProfilePath(tt.profile, customDir, systemDir)
+			got := profileSettingsGet(profile, []string{"sysctl", "sysfs"})
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got=%#v expected=%#v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestVerifySysctlSysfs(t *testing.T) {
+	root := t.TempDir()
+	procSys := filepath.Join(root, "proc", "sys")
+
+	writeTestFile(t, filepath.Join(procSys, "vm", "swappiness"), "60\n")
+	writeTestFile(t, filepath.Join(procSys, "net", "ipv4", "tcp_rmem"), "4096\t87380\t6291456\n")
+	writeTestFile(t, filepath.Join(procSys, "kernel", "pid_max"), "4194304\n")
+	writeTestFile(t, filepath.Join(root, "sys", "kernel", "mm", "transparent_hugepage", "enabled"), "always madvise [never]\n")
+	writeTestFile(t, filepath.Join(root, "sys", "kernel", "mm", "transparent_hugepage", "defrag"), "always defer [madvise] never\n")
+
+	drifted := verifySysctl(map[string]string{
+		"vm.swappiness":            "10",
+		"net.ipv4.tcp_rmem":        "4096 87380 6291456",
+		"kernel/pid_max":           "4194304",
+		"kernel.sched_nonexistent": "1",
+		"vm.dirty_ratio":           "${f:exec:echo:10}",
+	}, procSys)
+	expected := []string{"sysctl vm.swappiness=10 (have 60)"}
+	if !reflect.DeepEqual(drifted, expected) {
+		t.Errorf("sysctl: got=%#v expected=%#v", drifted, expected)
+	}
+
+	drifted = verifySysfs(map[string]string{
+		"/sys/kernel/mm/transparent_hugepage/enabled": "never",
+		"/sys/kernel/mm/transparent_hugepage/defrag":  "never",
+	}, root)
+	expected = []string{"sysfs /sys/kernel/mm/transparent_hugepage/defrag=never (have madvise)"}
+	if !reflect.DeepEqual(drifted, expected) {
+		t.Errorf("sysfs: got=%#v expected=%#v", drifted, expected)
+	}
+}
@@ -0,0 +1,54 @@
+package tunedprofile
+
+import (
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// loadOptions are the options of parsing TuneD profiles the same way as TuneD does.
+var loadOptions = ini.LoadOptions{
+	AllowPythonMultilineValues: true,
+	IgnoreInlineComment:        true,
+}
+
+// Include is a TuneD profile listed by the include= option of a TuneD profile.
+type Include struct {
+	// Name of the included TuneD profile.
+	Name string
+	// Conditional is true for TuneD profiles included with the '-' prefix,
+	// which need not exist.
+	Conditional bool
+}
+
+// Parse parses TuneD profile data 'data'.
+func Parse(data []byte) (*ini.File, error) {
+	return ini.LoadSources(loadOptions, data)
+}
+
+// Includes returns the TuneD profiles listed by the include= option of the [main]
+// section of TuneD profile 'cfg'.  Like TuneD, the option is expanded by 'expand'
+// (if not nil) before it is split into the TuneD profile names.
+func Includes(cfg *ini.File, expand func(string) string) []Include {
+	var includes []Include
+
+	main := cfg.Section(SectionMain)
+	if !main.HasKey(OptionInclude) {
+		return nil
+	}
+
+	include := main.Key(OptionInclude).String()
+	if expand != nil {
+		include = expand(include)
+	}
+	for _, name := range includeSeparatorRegex.Split(strings.TrimSpace(include), -1) {
+		conditional := strings.HasPrefix(name, "-")
+		name = strings.TrimPrefix(name, "-")
+		if name == "" {
+			continue
+		}
+		includes = append(includes, Include{Name: name, Conditional: conditional})
+	}
+
+	return includes
+}
@@ -0,0 +1,89 @@
+package tunedprofile
+
+import (
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+// scriptUnit is the name of the TuneD script plug-in unit and option; the scripts
+// of the included profiles are kept and run before the scripts of the including profile.
+const scriptUnit = "script"
+
+func newProfile(name string) *Profile {
+	return &Profile{
+		Name:      name,
+		Main:      map[string]string{},
+		Variables: map[string]string{},
+	}
+}
+
+// mergeProfile merges TuneD profile 'cfg' into the profile 'merged' which holds
+// the profiles loaded before.  The merge follows the TuneD semantics:
+//   - the options of the [main] and [variables] sections override the options
+//     of the same name,
+//   - the units not present yet are appended,
+//   - the units with replace=true replace the units of the same name,
+//   - the options listed by drop= are removed from the units of the same name,
+//   - the remaining unit options override the options of the same name.
+func mergeProfile(merged *Profile, cfg *ini.File) {
+	for _, section := range cfg.Sections() {
+		options := section.KeysHash()
+
+		switch section.Name() {
+		case ini.DefaultSection:
+			continue
+		case SectionMain:
+			delete(options, OptionInclude)
+			for k, v := range options {
+				merged.Main[k] = v
+			}
+			continue
+		case SectionVariables:
+			for k, v := range options {
+				merged.Variables[k] = v
+			}
+			continue
+		}
+
+		replace := parseBool(options[OptionReplace])
+		drop := options[OptionDrop]
+		delete(options, OptionReplace)
+		delete(options, OptionDrop)
+
+		unit := merged.Unit(section.Name())
+		if unit == nil {
+			merged.Units = append(merged.Units, Unit{Name: section.Name(), Options: options})
+			continue
+		}
+		if replace {
+			unit.Options = options
+			continue
+		}
+
+		for _, option := range strings.FieldsFunc(drop, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t' || r == '\n'
+		}) {
+			delete(unit.Options, option)
+		}
+
+		script, hasScript := unit.Options[scriptUnit]
+		for k, v := range options {
+			unit.Options[k] = v
+		}
+		if unit.Name == scriptUnit && hasScript {
+			if newScript, ok := options[scriptUnit]; ok {
+				unit.Options[scriptUnit] = script + " " + newScript
+			}
+		}
+	}
+}
+
+// parseBool parses the boolean TuneD option value 'value'.
+func parseBool(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "1", "true", "y", "yes", "on":
+		return true
+	}
+	return false
+}
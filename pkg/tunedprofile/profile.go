@@ -0,0 +1,163 @@
+// Package tunedprofile computes the effective TuneD profile, i.e. the profile
+// TuneD applies after resolving the includes and merging the included profiles.
+package tunedprofile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/ini.v1"
+)
+
+const (
+	// ConfFile is the name of the TuneD profile file in a profile directory.
+	ConfFile = "tuned.conf"
+
+	// SectionMain is the name of the TuneD profile section with the profile options.
+	SectionMain = "main"
+	// SectionVariables is the name of the TuneD profile section defining variables.
+	SectionVariables = "variables"
+
+	// OptionInclude is the [main] section option listing the included profiles.
+	OptionInclude = "include"
+	// OptionReplace is the unit option replacing the unit of the included profiles.
+	OptionReplace = "replace"
+	// OptionDrop is the unit option listing the options dropped from the unit of
+	// the included profiles.
+	OptionDrop = "drop"
+)
+
+// includeSeparatorRegex splits the list of the included profiles the same way
+// as TuneD; whitespace alone does not separate profile names.
+var includeSeparatorRegex = regexp.MustCompile(`\s*[,;]\s*`)
+
+// Unit is a section of a TuneD profile other than [main] and [variables].
+type Unit struct {
+	// Name of the unit (section).
+	Name string
+	// Options of the unit with the drop= and replace= options already applied.
+	Options map[string]string
+}
+
+// Profile is a TuneD profile with its includes resolved and merged.
+type Profile struct {
+	// Name of the profile(s) loaded, space-separated.
+	Name string
+	// Profiles lists the names of the profiles merged, in the order of merging.
+	Profiles []string
+	// Main holds the options of the [main] section except include=.
+	Main map[string]string
+	// Variables holds the options of the [variables] section.
+	Variables map[string]string
+	// Units holds the remaining sections in the order of their appearance.
+	Units []Unit
+}
+
+// Unit returns the unit 'name' of the profile or nil if there is none.
+func (p *Profile) Unit(name string) *Unit {
+	for i := range p.Units {
+		if p.Units[i].Name == name {
+			return &p.Units[i]
+		}
+	}
+	return nil
+}
+
+// Loader loads TuneD profiles from profile directories.
+type Loader struct {
+	// Dirs lists the profile directories such as /usr/lib/tuned and /etc/tuned
+	// from the lowest to the highest priority.
+	Dirs []string
+	// Expand optionally expands TuneD built-in functions and variables in
+	// the list of included profiles.
+	Expand func(string) string
+}
+
+// NewLoader returns a Loader of TuneD profiles from directories 'dirs' listed
+// from the lowest to the highest priority.
+func NewLoader(dirs ...string) *Loader {
+	return &Loader{Dirs: dirs}
+}
+
+// Load loads TuneD profiles 'names' with their includes and returns the effective
+// profile merged in the same way as TuneD merges multiple active profiles.
+func (l *Loader) Load(names ...string) (*Profile, error) {
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no TuneD profile to load")
+	}
+
+	merged := newProfile(strings.Join(names, " "))
+	processed := map[string]bool{}
+	for _, name := range names {
+		if err := l.load(merged, Include{Name: name}, processed); err != nil {
+			return nil, err
+		}
+	}
+
+	return merged, nil
+}
+
+// load merges TuneD profile 'include' and its includes into profile 'merged'.
+// 'processed' holds the files of the profiles already loaded, which also
+// prevents include loops.
+func (l *Loader) load(merged *Profile, include Include, processed map[string]bool) error {
+	name := include.Name
+
+	file, skipped := l.find(name, processed)
+	if file == "" {
+		if include.Conditional || skipped {
+			return nil
+		}
+		return fmt.Errorf("failed to find TuneD profile %s in %v", name, l.Dirs)
+	}
+	processed[file] = true
+
+	cfg, err := loadFile(file)
+	if err != nil {
+		return err
+	}
+
+	for _, included := range Includes(cfg, l.Expand) {
+		if err := l.load(merged, included, processed); err != nil {
+			return fmt.Errorf("failed to load TuneD profile %s included from %s: %v", included.Name, name, err)
+		}
+	}
+
+	merged.Profiles = append(merged.Profiles, name)
+	mergeProfile(merged, cfg)
+
+	return nil
+}
+
+// find returns the file of the highest-priority TuneD profile 'name' which was not
+// 'processed' yet.  Skipping the processed profiles allows a custom profile to include
+// the system profile of the same name and loads each profile only once.  Returns an
+// empty file name if no such profile exists and whether a processed profile was skipped.
+func (l *Loader) find(name string, processed map[string]bool) (string, bool) {
+	var skipped bool
+
+	for i := len(l.Dirs) - 1; i >= 0; i-- {
+		file := filepath.Join(l.Dirs[i], name, ConfFile)
+		if processed[file] {
+			skipped = true
+			continue
+		}
+		if _, err := os.Stat(file); err == nil {
+			return file, false
+		}
+	}
+
+	return "", skipped
+}
+
+// loadFile reads TuneD profile file 'file'.
+func loadFile(file string) (*ini.File, error) {
+	cfg, err := ini.LoadSources(loadOptions, file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read TuneD profile %s: %v", file, err)
+	}
+	return cfg, nil
+}
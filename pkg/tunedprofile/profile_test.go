@@ -0,0 +1,165 @@
+package tunedprofile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	loader := NewLoader("testdata/system", "testdata/custom")
+	loader.Expand = func(s string) string {
+		return strings.ReplaceAll(s, "${f:base_profile}", "network")
+	}
+
+	tests := []struct {
+		names       []string
+		expected    *Profile
+		expectedErr bool
+	}{
+		{
+			names: []string{"custom"},
+			expected: &Profile{
+				Name:      "custom",
+				Profiles:  []string{"base", "network", "openshift", "openshift", "custom"},
+				Main:      map[string]string{"summary": "Custom profile"},
+				Variables: map[string]string{"isolated_cores": "1-3"},
+				Units: []Unit{
+					{
+						Name:    "cpu",
+						Options: map[string]string{"governor": "powersave"},
+					},
+					{
+						Name: "sysctl",
+						Options: map[string]string{
+							"kernel.sched_min_granularity_ns": "10000000",
+							"vm.dirty_ratio":                  "30",
+							"net.core.busy_read":              "50",
+							"net.ipv4.ip_forward":             "1",
+							"kernel.pid_max":                  "4194304",
+						},
+					},
+					{
+						Name:    "script",
+						Options: map[string]string{"script": "base.sh custom.sh"},
+					},
+					{
+						Name:    "bootloader",
+						Options: map[string]string{"cmdline": "skew_tick=1"},
+					},
+				},
+			},
+		},
+		{
+			names: []string{"replace"},
+			expected: &Profile{
+				Name:      "replace",
+				Profiles:  []string{"base", "network", "replace"},
+				Main:      map[string]string{"summary": "Replacing profile"},
+				Variables: map[string]string{"isolated_cores": "1-3"},
+				Units: []Unit{
+					{
+						Name: "cpu",
+						Options: map[string]string{
+							"governor":         "performance",
+							"energy_perf_bias": "performance",
+							"min_perf_pct":     "100",
+						},
+					},
+					{
+						Name:    "sysctl",
+						Options: map[string]string{"vm.swappiness": "10"},
+					},
+					{
+						Name:    "script",
+						Options: map[string]string{"script": "base.sh"},
+					},
+				},
+			},
+		},
+		{
+			names: []string{"base", "loop-a"},
+			expected: &Profile{
+				Name:      "base loop-a",
+				Profiles:  []string{"base", "loop-b", "loop-a"},
+				Main:      map[string]string{"summary": "Base profile"},
+				Variables: map[string]string{"isolated_cores": "1-3"},
+				Units: []Unit{
+					{
+						Name: "cpu",
+						Options: map[string]string{
+							"governor":         "performance",
+							"energy_perf_bias": "performance",
+							"min_perf_pct":     "100",
+						},
+					},
+					{
+						Name: "sysctl",
+						Options: map[string]string{
+							"kernel.sched_min_granularity_ns": "10000000",
+							"vm.dirty_ratio":                  "40",
+						},
+					},
+					{
+						Name:    "script",
+						Options: map[string]string{"script": "base.sh"},
+					},
+				},
+			},
+		},
+		{
+			names:       []string{"missing"},
+			expectedErr: true,
+		},
+		{
+			names:       nil,
+			expectedErr: true,
+		},
+	}
+
+	for i, tc := range tests {
+		have, err := loader.Load(tc.names...)
+		if (err != nil) != tc.expectedErr {
+			t.Errorf("failed test case %d: unexpected error: %v", i, err)
+			continue
+		}
+		if !reflect.DeepEqual(have, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %+v\n\thave: %+v", i, tc.expected, have)
+		}
+	}
+}
+
+func TestIncludes(t *testing.T) {
+	tests := []struct {
+		include  string
+		expected []Include
+	}{
+		{
+			include:  "a,b;c",
+			expected: []Include{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+		},
+		{
+			include:  " a , -b ;\tc ",
+			expected: []Include{{Name: "a"}, {Name: "b", Conditional: true}, {Name: "c"}},
+		},
+		{
+			// Whitespace alone does not separate TuneD profile names.
+			include:  "a b, c",
+			expected: []Include{{Name: "a b"}, {Name: "c"}},
+		},
+		{
+			include:  "a,,;",
+			expected: []Include{{Name: "a"}},
+		},
+	}
+
+	for i, tc := range tests {
+		cfg, err := Parse([]byte("[main]\ninclude=" + tc.include + "\n"))
+		if err != nil {
+			t.Fatalf("failed test case %d: %v", i+1, err)
+		}
+		if have := Includes(cfg, nil); !reflect.DeepEqual(have, tc.expected) {
+			t.Errorf("failed test case %d:\n\twant: %v\n\thave: %v", i+1, tc.expected, have)
+		}
+	}
+}
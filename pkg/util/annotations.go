@@ -1,6 +1,8 @@
 package util
 
 import (
+	"strings"
+
 	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
 )
 
@@ -16,6 +18,8 @@ const (
 	DeferAlways DeferMode = "always"
 	// DeferUpdate means in-place updates/changes to only the contents of the currently used profile. Switches to different TuneD profiles are processed immediately without the need to reboot.
 	DeferUpdate DeferMode = "update"
+	// Values starting with "window " mean the changes carried will be deferred until the next maintenance window or node restart,
+	// whichever comes first.  Changes are applied within the window without the need to reboot.  See ParseDeferWindow().
 )
 
 func (dm DeferMode) String() string {
@@ -27,22 +31,38 @@ func IsImmediateUpdate(value DeferMode) bool {
 }
 
 func IsDeferredUpdate(value DeferMode) bool {
+	if IsWindowUpdate(value) {
+		_, err := ParseDeferWindow(value)
+		return err == nil
+	}
 	return value == DeferAlways || value == DeferUpdate
 }
 
 func GetDeferredUpdateAnnotation(anns map[string]string) DeferMode {
-	if anns == nil {
-		return DeferNever
-	}
+	value, _ := ParseDeferredUpdateAnnotation(anns)
+	return value
+}
+
+// ParseDeferredUpdateAnnotation returns the DeferMode requested by annotations 'anns'
+// and the parsed maintenance window for DeferMode values requesting one, nil otherwise.
+// Invalid values are treated as DeferNever.
+func ParseDeferredUpdateAnnotation(anns map[string]string) (DeferMode, *DeferWindow) {
 	val, ok := anns[tunedv1.TunedDeferredUpdate]
 	if !ok {
-		return DeferNever
+		return DeferNever, nil
 	}
 	value := DeferMode(val)
+	if IsWindowUpdate(value) {
+		window, err := ParseDeferWindow(value)
+		if err != nil {
+			return DeferNever, nil
+		}
+		return value, window
+	}
 	if !IsDeferredUpdate(value) {
-		return DeferNever
+		return DeferNever, nil
 	}
-	return value
+	return value, nil
 }
 
 func SetDeferredUpdateAnnotation(anns map[string]string, value DeferMode) map[string]string {
@@ -60,6 +80,17 @@ func DeleteDeferredUpdateAnnotation(anns map[string]string) map[string]string {
 	return ret
 }
 
+// HasDryRunAnnotation returns true if annotations 'anns' request the dry-run mode.
+func HasDryRunAnnotation(anns map[string]string) bool {
+	return anns[tunedv1.TunedDryRun] == "true"
+}
+
+// GetDiscardDeferredUpdateAnnotation returns the fingerprint of the pending deferred
+// update annotations 'anns' request to discard or "" if there is no such request.
+func GetDiscardDeferredUpdateAnnotation(anns map[string]string) string {
+	return strings.TrimSpace(anns[tunedv1.TunedDiscardDeferredUpdate])
+}
+
 func cloneMapStringString(obj map[string]string) map[string]string {
 	ret := make(map[string]string, len(obj))
 	for key, val := range obj {
@@ -56,6 +56,20 @@ func TestHasDeferredUpdateAnnotation(t *testing.T) {
 			},
 			expected: DeferNever,
 		},
+		{
+			name: "found-window",
+			anns: map[string]string{
+				"tuned.openshift.io/deferred": "window 0 2 * * 6 2h",
+			},
+			expected: DeferMode("window 0 2 * * 6 2h"),
+		},
+		{
+			name: "found-invalid-window",
+			anns: map[string]string{
+				"tuned.openshift.io/deferred": "window 0 2 * * 6",
+			},
+			expected: DeferNever,
+		},
 	}
 	for _, tt := range testCases {
 		t.Run(tt.name, func(t *testing.T) {
@@ -63,6 +77,9 @@ func TestHasDeferredUpdateAnnotation(t *testing.T) {
 			if got != tt.expected {
 				t.Errorf("got=%v expected=%v", got, tt.expected)
 			}
+			if _, window := ParseDeferredUpdateAnnotation(tt.anns); (window != nil) != IsWindowUpdate(tt.expected) {
+				t.Errorf("got window=%v expected window=%v", window != nil, IsWindowUpdate(tt.expected))
+			}
 		})
 	}
 }
@@ -164,3 +181,80 @@ func TestDeleteDeferredUpdateAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestHasDryRunAnnotation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		anns     map[string]string
+		expected bool
+	}{
+		{
+			name:     "nil",
+			expected: false,
+		},
+		{
+			name: "no-ann",
+			anns: map[string]string{
+				"foo": "bar",
+			},
+			expected: false,
+		},
+		{
+			name: "found-false",
+			anns: map[string]string{
+				"tuned.openshift.io/dry-run": "false",
+			},
+			expected: false,
+		},
+		{
+			name: "found-true",
+			anns: map[string]string{
+				"tuned.openshift.io/dry-run": "true",
+			},
+			expected: true,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HasDryRunAnnotation(tt.anns)
+			if got != tt.expected {
+				t.Errorf("got=%v expected=%v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetDiscardDeferredUpdateAnnotation(t *testing.T) {
+	testCases := []struct {
+		name     string
+		anns     map[string]string
+		expected string
+	}{
+		{
+			name:     "nil",
+			expected: "",
+		},
+		{
+			name: "no-ann",
+			anns: map[string]string{
+				"foo": "bar",
+			},
+			expected: "",
+		},
+		{
+			name: "found",
+			anns: map[string]string{
+				"tuned.openshift.io/discard-deferred": " 0123abcd\n",
+			},
+			expected: "0123abcd",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetDiscardDeferredUpdateAnnotation(tt.anns)
+			if got != tt.expected {
+				t.Errorf("got=%v expected=%v", got, tt.expected)
+			}
+		})
+	}
+}
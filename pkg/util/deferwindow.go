@@ -0,0 +1,76 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron"
+)
+
+// deferWindowPrefix is the prefix of the DeferMode values requesting the changes
+// to be deferred until the next maintenance window.
+const deferWindowPrefix = "window "
+
+// DeferWindow is a recurring maintenance window in which deferred changes are applied.
+type DeferWindow struct {
+	// spec is the cron specification of the window start times.
+	spec string
+	// schedule are the start times of the window.
+	schedule cron.Schedule
+	// duration is the length of the window.
+	duration time.Duration
+}
+
+// IsWindowUpdate returns true if 'value' requests the changes to be deferred
+// until the next maintenance window.
+func IsWindowUpdate(value DeferMode) bool {
+	return strings.HasPrefix(string(value), deferWindowPrefix)
+}
+
+// ParseDeferWindow parses DeferMode 'value' in the format
+// "window <minute> <hour> <day of month> <month> <day of week> <duration>",
+// where the window start times are in the standard cron format evaluated in UTC
+// and the duration is a Go duration string, e.g. "window 0 2 * * 6 2h".
+func ParseDeferWindow(value DeferMode) (*DeferWindow, error) {
+	if !IsWindowUpdate(value) {
+		return nil, fmt.Errorf("%q is not a maintenance window", value)
+	}
+
+	fields := strings.Fields(strings.TrimPrefix(string(value), deferWindowPrefix))
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("maintenance window %q needs a schedule and a duration", value)
+	}
+
+	spec := strings.Join(fields[:len(fields)-1], " ")
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maintenance window %q schedule: %v", value, err)
+	}
+	duration, err := time.ParseDuration(fields[len(fields)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid maintenance window %q duration: %v", value, err)
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("invalid maintenance window %q duration: must be positive", value)
+	}
+
+	return &DeferWindow{spec: spec, schedule: schedule, duration: duration}, nil
+}
+
+// String returns the schedule and the duration of the maintenance window.
+func (w *DeferWindow) String() string {
+	return fmt.Sprintf("%s %v", w.spec, w.duration)
+}
+
+// Open returns true if time 'now' is within the maintenance window.
+func (w *DeferWindow) Open(now time.Time) bool {
+	// The first window start after 'now - duration' is the start of the window 'now' might be in.
+	start := w.schedule.Next(now.UTC().Add(-w.duration))
+	return !start.After(now.UTC())
+}
+
+// Next returns the start of the next maintenance window after time 'now'.
+func (w *DeferWindow) Next(now time.Time) time.Time {
+	return w.schedule.Next(now.UTC())
+}
@@ -0,0 +1,142 @@
+package util
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDeferWindow(t *testing.T) {
+	testCases := []struct {
+		name      string
+		value     DeferMode
+		expectErr bool
+	}{
+		{
+			name:  "cron",
+			value: "window 0 2 * * 6 2h",
+		},
+		{
+			name:  "descriptor",
+			value: "window @daily 30m",
+		},
+		{
+			name:      "not-window",
+			value:     DeferAlways,
+			expectErr: true,
+		},
+		{
+			name:      "missing-duration",
+			value:     "window 0 2 * * 6",
+			expectErr: true,
+		},
+		{
+			name:      "invalid-schedule",
+			value:     "window 0 25 * * * 1h",
+			expectErr: true,
+		},
+		{
+			name:      "invalid-duration",
+			value:     "window 0 2 * * * 2d",
+			expectErr: true,
+		},
+		{
+			name:      "negative-duration",
+			value:     "window 0 2 * * * -1h",
+			expectErr: true,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDeferWindow(tt.value)
+			if (err != nil) != tt.expectErr {
+				t.Errorf("got=%v expectErr=%v", err, tt.expectErr)
+			}
+		})
+	}
+}
+
+func TestDeferWindow(t *testing.T) {
+	// Saturdays 02:00-04:00 UTC.
+	window, err := ParseDeferWindow("window 0 2 * * 6 2h")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name         string
+		now          time.Time
+		expectedOpen bool
+		expectedNext time.Time
+	}{
+		{
+			name:         "before",
+			now:          time.Date(2024, time.June, 15, 1, 59, 0, 0, time.UTC),
+			expectedOpen: false,
+			expectedNext: time.Date(2024, time.June, 15, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "start",
+			now:          time.Date(2024, time.June, 15, 2, 0, 0, 0, time.UTC),
+			expectedOpen: true,
+			expectedNext: time.Date(2024, time.June, 22, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "within",
+			now:          time.Date(2024, time.June, 15, 3, 30, 0, 0, time.UTC),
+			expectedOpen: true,
+			expectedNext: time.Date(2024, time.June, 22, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "end",
+			now:          time.Date(2024, time.June, 15, 4, 0, 0, 0, time.UTC),
+			expectedOpen: false,
+			expectedNext: time.Date(2024, time.June, 22, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "within-other-timezone",
+			now:          time.Date(2024, time.June, 15, 5, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			expectedOpen: true,
+			expectedNext: time.Date(2024, time.June, 22, 2, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := window.Open(tt.now); got != tt.expectedOpen {
+				t.Errorf("got=%v expected=%v", got, tt.expectedOpen)
+			}
+			if got := window.Next(tt.now); !got.Equal(tt.expectedNext) {
+				t.Errorf("got=%v expected=%v", got, tt.expectedNext)
+			}
+		})
+	}
+}
+
+func TestDeferWindowString(t *testing.T) {
+	testCases := []struct {
+		name     string
+		value    DeferMode
+		expected string
+	}{
+		{
+			name:     "cron",
+			value:    "window 0 2 * * 6 2h",
+			expected: "0 2 * * 6 2h0m0s",
+		},
+		{
+			name:     "descriptor",
+			value:    "window  @daily   30m",
+			expected: "@daily 30m0s",
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			window, err := ParseDeferWindow(tt.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := window.String(); got != tt.expected {
+				t.Errorf("got=%v expected=%v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/klog/v2"
 
 	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
 	"github.com/openshift/cluster-node-tuning-operator/pkg/performanceprofile/controller/performanceprofile/components"
@@ -78,6 +79,53 @@ func ParseManifests(filename string, r io.Reader) ([]manifest, error) {
 	}
 }
 
+// DecodeManifestsFromFile decodes the kubernetes resources in file 'filename' read
+// from 'r' by 'decoder' and passes them to 'add'.  Resources of kinds unknown to
+// 'decoder' are skipped.
+func DecodeManifestsFromFile(filename string, r io.Reader, decoder runtime.Decoder, add func(obj runtime.Object) error) error {
+	manifests, err := ParseManifests(filename, r)
+	if err != nil {
+		return fmt.Errorf("error parsing manifests from %s: %w", filename, err)
+	}
+
+	klog.V(4).Infof("decoding manifests for file %s...", filename)
+	for idx, m := range manifests {
+		obj, err := runtime.Decode(decoder, m.Raw)
+		if err != nil {
+			if runtime.IsNotRegisteredError(err) || runtime.IsMissingKind(err) {
+				klog.V(4).Infof("skipping path %q [%d] manifest: %v", filename, idx+1, err)
+				continue
+			}
+			return fmt.Errorf("error parsing %q [%d] manifest: %w", filename, idx+1, err)
+		}
+		if err := add(obj); err != nil {
+			return fmt.Errorf("error parsing %q [%d] manifest: %w", filename, idx+1, err)
+		}
+	}
+
+	return nil
+}
+
+// DecodeManifestsFromFiles decodes the kubernetes resources in files 'filePaths'
+// the same way as DecodeManifestsFromFile.
+func DecodeManifestsFromFiles(filePaths []string, decoder runtime.Decoder, add func(obj runtime.Object) error) error {
+	for _, path := range filePaths {
+		err := func() error {
+			file, err := os.Open(path)
+			if err != nil {
+				return fmt.Errorf("error opening %s: %w", path, err)
+			}
+			defer file.Close()
+			return DecodeManifestsFromFile(path, file, decoder, add)
+		}()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func ListFiles(dirPaths string) ([]string, error) {
 	dirs := strings.Split(dirPaths, ",")
 	return ListFilesFromMultiplePaths(dirs)